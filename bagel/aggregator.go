@@ -0,0 +1,143 @@
+package bagel
+
+import (
+	"log"
+	"math"
+)
+
+// constants are used as the operation of an aggregator
+const (
+	AGGREGATE_SUM   = "sum"
+	AGGREGATE_MIN   = "min"
+	AGGREGATE_MAX   = "max"
+	AGGREGATE_COUNT = "count"
+)
+
+// superStepContext holds the superstep state shared by the vertices of a
+// worker while they are computed
+type superStepContext struct {
+	superStepNum uint64
+	// operation of every aggregator of the program
+	aggregators map[string]string
+	// global aggregator values of the previous superstep
+	aggregated map[string]float64
+	// aggregator values of the worker's vertices at this superstep
+	partials map[string]float64
+	// state set by the program's MasterComputer
+	master MasterState
+	// topology mutations requested by the worker's vertices
+	mutations []Mutation
+}
+
+func newSuperStepContext(
+	superStepNum uint64, program VertexProgram, aggregated map[string]float64,
+	master MasterState,
+) *superStepContext {
+	return &superStepContext{
+		superStepNum: superStepNum,
+		aggregators:  aggregatorsOf(program),
+		aggregated:   aggregated,
+		partials:     make(map[string]float64),
+		master:       master,
+	}
+}
+
+// aggregatorsOf returns the aggregators of the program, or nil if it has none
+func aggregatorsOf(program VertexProgram) map[string]string {
+	if aggregatorProgram, ok := program.(AggregatorProgram); ok {
+		return aggregatorProgram.Aggregators()
+	}
+	return nil
+}
+
+func isAggregateOp(op string) bool {
+	switch op {
+	case AGGREGATE_SUM, AGGREGATE_MIN, AGGREGATE_MAX, AGGREGATE_COUNT:
+		return true
+	default:
+		return false
+	}
+}
+
+// reduceAggregate combines two values of an aggregator. Counts are reduced
+// like sums since each value is already a count
+func reduceAggregate(op string, a float64, b float64) float64 {
+	switch op {
+	case AGGREGATE_MIN:
+		return math.Min(a, b)
+	case AGGREGATE_MAX:
+		return math.Max(a, b)
+	default:
+		return a + b
+	}
+}
+
+// addAggregate reduces value into the named aggregator of values
+func addAggregate(
+	values map[string]float64, name string, op string, value float64,
+) {
+	if current, exists := values[name]; exists {
+		values[name] = reduceAggregate(op, current, value)
+		return
+	}
+	values[name] = value
+}
+
+// reduceAggregates reduces the aggregator values reported by every worker
+// into the global aggregator values
+func reduceAggregates(
+	aggregators map[string]string, partials []map[string]float64,
+) map[string]float64 {
+	if len(aggregators) == 0 {
+		return nil
+	}
+
+	aggregated := make(map[string]float64)
+	for _, partial := range partials {
+		for name, value := range partial {
+			op, exists := aggregators[name]
+			if !exists {
+				log.Printf("reduceAggregates: unknown aggregator %v\n", name)
+				continue
+			}
+			addAggregate(aggregated, name, op, value)
+		}
+	}
+	return aggregated
+}
+
+// SuperStepNum returns the number of the superstep the vertex is computing
+func (v *Vertex) SuperStepNum() uint64 {
+	if v.superStep == nil {
+		return 0
+	}
+	return v.superStep.superStepNum
+}
+
+// Aggregated returns the value of the named aggregator over every vertex at
+// the previous superstep, and false if no vertex contributed to it
+func (v *Vertex) Aggregated(name string) (float64, bool) {
+	if v.superStep == nil {
+		return 0, false
+	}
+	value, exists := v.superStep.aggregated[name]
+	return value, exists
+}
+
+// Aggregate contributes value to the named aggregator of the superstep. The
+// value is ignored by count aggregators, which count the contributions
+func (v *Vertex) Aggregate(name string, value float64) {
+	if v.superStep == nil {
+		return
+	}
+
+	op, exists := v.superStep.aggregators[name]
+	if !exists {
+		log.Printf("WARNING - Aggregate: unknown aggregator %v\n", name)
+		return
+	}
+	if op == AGGREGATE_COUNT {
+		value = 1
+	}
+	addAggregate(v.superStep.partials, name, op, value)
+}
@@ -0,0 +1,86 @@
+package bagel
+
+import (
+	"io"
+	"log"
+	"os"
+	"project/database/mongodb"
+	"reflect"
+	"testing"
+)
+
+func TestReduceAggregates(t *testing.T) {
+	aggregators := map[string]string{
+		"total":    AGGREGATE_SUM,
+		"smallest": AGGREGATE_MIN,
+		"largest":  AGGREGATE_MAX,
+		"vertices": AGGREGATE_COUNT,
+	}
+	partials := []map[string]float64{
+		{"total": 1.5, "smallest": 3, "largest": 3, "vertices": 2},
+		{"total": 2, "smallest": -1, "largest": 7, "vertices": 5},
+		{},
+	}
+
+	expected := map[string]float64{
+		"total": 3.5, "smallest": -1, "largest": 7, "vertices": 7,
+	}
+	aggregated := reduceAggregates(aggregators, partials)
+	if !reflect.DeepEqual(aggregated, expected) {
+		t.Errorf("expected aggregates %v but got %v", expected, aggregated)
+	}
+}
+
+func TestVertexAggregate(t *testing.T) {
+	superStep := &superStepContext{
+		superStepNum: 4,
+		aggregators: map[string]string{
+			"largest": AGGREGATE_MAX, "vertices": AGGREGATE_COUNT,
+		},
+		aggregated: map[string]float64{"largest": 10},
+		partials:   make(map[string]float64),
+	}
+	vertices := []*Vertex{NewVertex(1, nil), NewVertex(2, nil)}
+	for idx, vertex := range vertices {
+		vertex.superStep = superStep
+		vertex.Aggregate("largest", float64(idx+5))
+		vertex.Aggregate("vertices", 100)
+		vertex.Aggregate("unknown", 1)
+	}
+
+	expected := map[string]float64{"largest": 6, "vertices": 2}
+	if !reflect.DeepEqual(superStep.partials, expected) {
+		t.Errorf(
+			"expected partial aggregates %v but got %v", expected,
+			superStep.partials,
+		)
+	}
+	if value, exists := vertices[0].Aggregated("largest"); !exists ||
+		value != 10 {
+		t.Errorf("expected aggregated value 10 but got %v", value)
+	}
+	if vertices[0].SuperStepNum() != 4 {
+		t.Errorf("expected superstep 4 but got %v", vertices[0].SuperStepNum())
+	}
+}
+
+func TestPageRankReportsDelta(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	w := newTestWorker(
+		t, Query{QueryType: PAGE_RANK, Nodes: []uint64{1}},
+		[]mongodb.Vertex{
+			{ID: 1, Edges: []uint64{2}},
+			{ID: 2, Edges: []uint64{1}},
+		},
+	)
+
+	var resp ProgressSuperStepResult
+	w.ComputeVertices(&ProgressSuperStep{SuperStepNum: 1}, &resp)
+
+	// both vertices go from 0 to a PageRank of 1
+	if delta := resp.Aggregates[PAGE_RANK_DELTA]; !almostEqual(delta, 2) {
+		t.Errorf("expected a total delta of 2 but got %v", delta)
+	}
+}
@@ -0,0 +1,493 @@
+package bagel
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math"
+	coordgRPC "project/bagel/proto/coord"
+)
+
+// query parameters of betweenness queries
+const (
+	// BETWEENNESS_NUM_SAMPLES is the number of sampled sources. Every
+	// vertex is a source if the graph has fewer vertices, which computes
+	// the exact betweenness
+	BETWEENNESS_NUM_SAMPLES = "NumSamples"
+	// BETWEENNESS_SEED picks the sampled sources of a query
+	BETWEENNESS_SEED = "Seed"
+)
+
+// defaultBetweennessNumSamples is the number of sampled sources of a query
+// that does not set it
+const defaultBetweennessNumSamples = 32
+
+// the sources are the vertices with the NumSamples smallest sample keys, a
+// hash of their id and the seed of 53 bits so that it is exact as a float64.
+// Every round of the sampling counts the keys in betweennessNumBuckets
+// buckets of a range of keys, and narrows the range to the bucket holding
+// the largest key of the sample
+const (
+	betweennessKeyBits    = 53
+	betweennessNumBuckets = 64
+	// width of the buckets of the first round, which cover every key
+	betweennessFirstWidth = (1 << betweennessKeyBits) / betweennessNumBuckets
+)
+
+// phases of a betweenness query, after a first superstep counting the
+// vertices and the keys of the first round of the sampling
+const (
+	// the vertices count their keys in the buckets of the range of the
+	// round
+	BETWEENNESS_SAMPLE = "Sample"
+	// the sampled sources search the graph at the same time, counting the
+	// shortest paths from them to every vertex
+	BETWEENNESS_FORWARD = "Forward"
+	// the dependencies of the sources on every vertex are accumulated from
+	// the vertices furthest from the sources back to them, one distance per
+	// superstep
+	BETWEENNESS_BACKWARD = "Backward"
+)
+
+// aggregators and broadcast values of a betweenness query
+const (
+	BETWEENNESS_NUM_VERTICES = "BetweennessVertices"
+	BETWEENNESS_NUM_SOURCES  = "BetweennessSources"
+	// largest distance from a source to a vertex
+	BETWEENNESS_MAX_DISTANCE = "BetweennessMaxDistance"
+	// range of keys of a round of the sampling, starting at the smallest
+	// key of its first bucket, with the number of keys below it
+	BETWEENNESS_SAMPLE_LOW   = "BetweennessSampleLow"
+	BETWEENNESS_SAMPLE_WIDTH = "BetweennessSampleWidth"
+	BETWEENNESS_SAMPLE_BELOW = "BetweennessSampleBelow"
+	// largest key of a source
+	BETWEENNESS_SAMPLE_THRESHOLD = "BetweennessSampleThreshold"
+	// distance of the vertices sending their dependencies at a superstep of
+	// the backward phase
+	BETWEENNESS_DISTANCE = "BetweennessDistance"
+)
+
+// BetweennessResult is the result of a betweenness query: the score of the
+// queried vertex, or the best ranked vertices of a TopK query, along with
+// the number of sources the scores are estimated from
+type BetweennessResult struct {
+	Score      float64
+	NumSources uint64
+	Ranking    []RankedVertex
+}
+
+// Ranked returns the ranking of a TopK query, the number of sources being
+// sent apart
+func (r BetweennessResult) Ranked() []RankedVertex {
+	return r.Ranking
+}
+
+// FillQueryResult sends the score as the Result, along with the number of
+// sources
+func (r BetweennessResult) FillQueryResult(reply *coordgRPC.QueryResult) {
+	reply.Result = r.Score
+	reply.NumSources = r.NumSources
+}
+
+// BetweennessVertex is the value of a vertex in a betweenness query
+type BetweennessVertex struct {
+	// estimated betweenness, once the backward phase is over
+	Score    float64
+	IsSource bool
+	// shortest paths from every source reaching the vertex
+	Paths map[uint64]BrandesPath
+}
+
+// NumericValue is the betweenness of the vertex, so VertexValues and TopK
+// queries return the scores
+func (b BetweennessVertex) NumericValue() (float64, bool) {
+	return b.Score, true
+}
+
+// BrandesPath is what a vertex knows of the shortest paths from a source
+type BrandesPath struct {
+	Distance int
+	NumPaths float64
+	// vertices before the vertex on the shortest paths
+	Predecessors []uint64
+	// dependency of the source on the vertex, accumulated from the vertices
+	// after it on the shortest paths
+	Dependency float64
+}
+
+// brandesForward is sent along the out-edges during the forward phase with
+// the number of shortest paths from the source to the sender
+type brandesForward struct {
+	Source   uint64
+	Distance int
+	NumPaths float64
+}
+
+// brandesBackward is sent to the predecessors during the backward phase with
+// (1 + dependency) / number of paths of the sender
+type brandesBackward struct {
+	Source     uint64
+	Dependency float64
+}
+
+// betweennessProgram estimates the betweenness centrality of the vertices
+// with Brandes' algorithm from a sample of the vertices as sources, along
+// the out-edges. Every source searches the graph breadth-first, counting the
+// shortest paths to every vertex, then the dependencies of the source are
+// accumulated back along the shortest paths. The betweenness of a vertex is
+// the sum of the dependencies of the sources on it, scaled by the number of
+// vertices over the number of sources. The MasterComputer first picks
+// exactly NumSamples sources, before the forward and backward phases
+type betweennessProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(BETWEENNESS, newBetweennessProgram)
+
+	// vertex values are checkpointed, and messages and partial results are
+	// sent as an interface{}
+	gob.Register(BetweennessVertex{})
+	gob.Register(brandesForward{})
+	gob.Register(brandesBackward{})
+	gob.Register(BetweennessResult{})
+}
+
+func newBetweennessProgram(query Query) VertexProgram {
+	return &betweennessProgram{query: query}
+}
+
+func (p *betweennessProgram) Validate() error {
+	if p.query.IsTargetQuery() && len(p.query.Nodes) != 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	if !p.query.IsTargetQuery() && len(p.query.Nodes) > 0 {
+		return errors.New("betweenness ranks or returns every vertex")
+	}
+	if !p.query.IsIntParam(
+		BETWEENNESS_NUM_SAMPLES, defaultBetweennessNumSamples, 1,
+		math.MaxInt32,
+	) {
+		return errors.New("betweenness needs a whole number of sources")
+	}
+	if !p.query.IsIntParam(BETWEENNESS_SEED, 0, -1<<53, 1<<53) {
+		return errors.New("betweenness seed must be a whole number")
+	}
+	return nil
+}
+
+func (p *betweennessProgram) numSamples() float64 {
+	return p.query.Param(BETWEENNESS_NUM_SAMPLES, defaultBetweennessNumSamples)
+}
+
+func (p *betweennessProgram) InitialValue(v *Vertex) interface{} {
+	return BetweennessVertex{}
+}
+
+func (p *betweennessProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	switch v.Phase() {
+	case "":
+		v.Aggregate(BETWEENNESS_NUM_VERTICES, 1)
+		p.countSampleKey(v)
+	case BETWEENNESS_SAMPLE:
+		p.countSampleKey(v)
+	case BETWEENNESS_FORWARD:
+		return p.forward(v)
+	case BETWEENNESS_BACKWARD:
+		return p.backward(v)
+	}
+	return nil
+}
+
+// sampleKey returns the sample key of the vertex, from a hash of its id and
+// the seed of the query
+func (p *betweennessProgram) sampleKey(v *Vertex) uint64 {
+	seed := hashVertexId(uint64(int64(p.query.Param(BETWEENNESS_SEED, 0))))
+	return hashVertexId(v.Id^seed) >> (64 - betweennessKeyBits)
+}
+
+// countSampleKey counts the key of the vertex in its bucket, if it is in the
+// range of the round
+func (p *betweennessProgram) countSampleKey(v *Vertex) {
+	low, _ := v.BroadcastValue(BETWEENNESS_SAMPLE_LOW)
+	width, isNarrowed := v.BroadcastValue(BETWEENNESS_SAMPLE_WIDTH)
+	if !isNarrowed {
+		width = betweennessFirstWidth
+	}
+	key := p.sampleKey(v)
+	if key < uint64(low) {
+		return
+	}
+	bucket := (key - uint64(low)) / uint64(width)
+	if bucket < betweennessNumBuckets {
+		v.Aggregate(betweennessBucket(int(bucket)), 1)
+	}
+}
+
+// isSampled reports whether the vertex is a source
+func (p *betweennessProgram) isSampled(v *Vertex) bool {
+	threshold, _ := v.BroadcastValue(BETWEENNESS_SAMPLE_THRESHOLD)
+	return p.sampleKey(v) <= uint64(threshold)
+}
+
+// betweennessBucket returns the name of the aggregator counting the keys of
+// a bucket
+func betweennessBucket(bucket int) string {
+	return fmt.Sprintf("BetweennessSampleBucket%v", bucket)
+}
+
+// forward starts the search of a sampled vertex at the start of the phase,
+// and records the shortest paths from the sources reaching the vertex for
+// the first time. Sources reach a vertex at a single superstep since they
+// search in lockstep, so later messages come from longer paths
+func (p *betweennessProgram) forward(v *Vertex) []Message {
+	state := v.CurrentValue.(BetweennessVertex)
+	paths := make(map[uint64]BrandesPath, len(state.Paths)+1)
+	for sourceVertexId, path := range state.Paths {
+		paths[sourceVertexId] = path
+	}
+
+	var reached []uint64
+	if v.IsPhaseStart() && p.isSampled(v) {
+		state.IsSource = true
+		paths[v.Id] = BrandesPath{NumPaths: 1}
+		reached = append(reached, v.Id)
+		v.Aggregate(BETWEENNESS_NUM_SOURCES, 1)
+	}
+	for _, message := range v.Messages {
+		value := message.Value.(brandesForward)
+		path, exists := paths[value.Source]
+		if exists && path.Distance < value.Distance {
+			continue
+		}
+		if !exists {
+			path = BrandesPath{Distance: value.Distance}
+			reached = append(reached, value.Source)
+		}
+		path.NumPaths += value.NumPaths
+		path.Predecessors = append(path.Predecessors, message.SourceVertexId)
+		paths[value.Source] = path
+	}
+	if len(reached) == 0 {
+		return nil
+	}
+	state.Paths = paths
+	v.CurrentValue = state
+
+	var result []Message
+	for _, sourceVertexId := range reached {
+		path := paths[sourceVertexId]
+		v.Aggregate(BETWEENNESS_MAX_DISTANCE, float64(path.Distance))
+		for _, neighborVertexId := range v.Neighbors {
+			if neighborVertexId == v.Id {
+				continue
+			}
+			result = append(
+				result, Message{
+					SourceVertexId: v.Id,
+					DestVertexId:   neighborVertexId,
+					Value: brandesForward{
+						Source:   sourceVertexId,
+						Distance: path.Distance + 1,
+						NumPaths: path.NumPaths,
+					},
+				},
+			)
+		}
+	}
+	return result
+}
+
+// backward adds the dependencies received from the vertices after the
+// vertex, and sends the vertex's dependencies on the sources at the
+// superstep's distance to its predecessors. The last superstep, at distance
+// 1, sets the score of the vertex
+func (p *betweennessProgram) backward(v *Vertex) []Message {
+	state := v.CurrentValue.(BetweennessVertex)
+	paths := make(map[uint64]BrandesPath, len(state.Paths))
+	for sourceVertexId, path := range state.Paths {
+		paths[sourceVertexId] = path
+	}
+	for _, message := range v.Messages {
+		value := message.Value.(brandesBackward)
+		path := paths[value.Source]
+		path.Dependency += path.NumPaths * value.Dependency
+		paths[value.Source] = path
+	}
+	state.Paths = paths
+
+	distance, _ := v.BroadcastValue(BETWEENNESS_DISTANCE)
+	var result []Message
+	if distance <= 1 {
+		// the dependency of a source on itself is not counted, since
+		// nothing is sent to the sources
+		numVertices, _ := v.BroadcastValue(BETWEENNESS_NUM_VERTICES)
+		numSources, _ := v.BroadcastValue(BETWEENNESS_NUM_SOURCES)
+		for _, path := range paths {
+			state.Score += path.Dependency
+		}
+		state.Score *= numVertices / numSources
+		state.Paths = nil
+	}
+	for sourceVertexId, path := range paths {
+		if path.Distance != int(distance) || distance <= 1 {
+			continue
+		}
+		for _, predecessorVertexId := range path.Predecessors {
+			result = append(
+				result, Message{
+					SourceVertexId: v.Id,
+					DestVertexId:   predecessorVertexId,
+					Value: brandesBackward{
+						Source:     sourceVertexId,
+						Dependency: (1 + path.Dependency) / path.NumPaths,
+					},
+				},
+			)
+		}
+	}
+	v.CurrentValue = state
+	return result
+}
+
+func (p *betweennessProgram) Aggregators() map[string]string {
+	aggregators := map[string]string{
+		BETWEENNESS_NUM_VERTICES: AGGREGATE_COUNT,
+		BETWEENNESS_NUM_SOURCES:  AGGREGATE_COUNT,
+		BETWEENNESS_MAX_DISTANCE: AGGREGATE_MAX,
+	}
+	for bucket := 0; bucket < betweennessNumBuckets; bucket++ {
+		aggregators[betweennessBucket(bucket)] = AGGREGATE_COUNT
+	}
+	return aggregators
+}
+
+// MasterCompute samples the sources once the vertices are counted, keeps
+// the largest distance of the forward phase, and moves the backward phase
+// one distance closer to the sources every superstep. The query ends when
+// the searches are over if no vertex is 2 hops from a source, or after the
+// backward superstep at distance 1
+func (p *betweennessProgram) MasterCompute(master *MasterContext) {
+	switch master.Phase() {
+	case "":
+		numVertices := master.Aggregated[BETWEENNESS_NUM_VERTICES]
+		master.Broadcast(BETWEENNESS_NUM_VERTICES, numVertices)
+		if p.numSamples() >= numVertices {
+			master.Broadcast(
+				BETWEENNESS_SAMPLE_THRESHOLD, 1<<betweennessKeyBits,
+			)
+			master.SetPhase(BETWEENNESS_FORWARD)
+			return
+		}
+		p.narrowSample(master)
+	case BETWEENNESS_SAMPLE:
+		p.narrowSample(master)
+	case BETWEENNESS_FORWARD:
+		if master.IsPhaseStart {
+			master.Broadcast(
+				BETWEENNESS_NUM_SOURCES,
+				master.Aggregated[BETWEENNESS_NUM_SOURCES],
+			)
+		}
+		maxDistance, _ := master.BroadcastValue(BETWEENNESS_MAX_DISTANCE)
+		if distance, exists := master.Aggregated[BETWEENNESS_MAX_DISTANCE]; exists &&
+			distance > maxDistance {
+			maxDistance = distance
+			master.Broadcast(BETWEENNESS_MAX_DISTANCE, maxDistance)
+		}
+		if master.AllWorkersInactive && maxDistance >= 2 {
+			master.SetPhase(BETWEENNESS_BACKWARD)
+			master.Broadcast(BETWEENNESS_DISTANCE, maxDistance)
+		}
+	case BETWEENNESS_BACKWARD:
+		distance, _ := master.BroadcastValue(BETWEENNESS_DISTANCE)
+		if distance > 1 {
+			master.SetPhase(BETWEENNESS_BACKWARD)
+			master.Broadcast(BETWEENNESS_DISTANCE, distance-1)
+		}
+	}
+}
+
+// narrowSample finds the bucket of the round holding the largest key of the
+// sample, and either narrows the range of the next round to it or, once the
+// buckets hold a single key, starts the forward phase with that key as the
+// threshold
+func (p *betweennessProgram) narrowSample(master *MasterContext) {
+	low, _ := master.BroadcastValue(BETWEENNESS_SAMPLE_LOW)
+	width, isNarrowed := master.BroadcastValue(BETWEENNESS_SAMPLE_WIDTH)
+	if !isNarrowed {
+		width = betweennessFirstWidth
+	}
+	below, _ := master.BroadcastValue(BETWEENNESS_SAMPLE_BELOW)
+
+	bucket := 0
+	for ; bucket < betweennessNumBuckets-1; bucket++ {
+		numKeys := master.Aggregated[betweennessBucket(bucket)]
+		if below+numKeys >= p.numSamples() {
+			break
+		}
+		below += numKeys
+	}
+	low += float64(bucket) * width
+	if width == 1 {
+		master.Broadcast(BETWEENNESS_SAMPLE_THRESHOLD, low)
+		master.SetPhase(BETWEENNESS_FORWARD)
+		return
+	}
+	master.Broadcast(BETWEENNESS_SAMPLE_LOW, low)
+	master.Broadcast(
+		BETWEENNESS_SAMPLE_WIDTH, math.Max(1, width/betweennessNumBuckets),
+	)
+	master.Broadcast(BETWEENNESS_SAMPLE_BELOW, below)
+	master.SetPhase(BETWEENNESS_SAMPLE)
+}
+
+// PartialResult returns the number of sources among the worker's vertices,
+// along with the score of the queried vertex or the worker's best ranked
+// vertices
+func (p *betweennessProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	partial := BetweennessResult{}
+	candidates := make([]RankedVertex, 0)
+	for _, vertex := range vertices {
+		state := vertex.CurrentValue.(BetweennessVertex)
+		if state.IsSource {
+			partial.NumSources++
+		}
+		if p.query.IsTargetQuery() && vertex.Id == p.query.Nodes[0] {
+			partial.Score = state.Score
+		}
+		if p.query.IsTopKQuery() {
+			candidates = append(
+				candidates, RankedVertex{
+					VertexId: vertex.Id, Score: state.Score,
+				},
+			)
+		}
+	}
+	if p.query.IsTopKQuery() {
+		partial.Ranking = topK(candidates, int(p.query.TopK))
+	}
+	return partial
+}
+
+// MergeResults returns the BetweennessResult of the query
+func (p *betweennessProgram) MergeResults(
+	partials []interface{},
+) interface{} {
+	result := BetweennessResult{}
+	rankings := make([][]RankedVertex, 0, len(partials))
+	for _, partial := range partials {
+		betweenness := partial.(BetweennessResult)
+		result.NumSources += betweenness.NumSources
+		result.Score += betweenness.Score
+		rankings = append(rankings, betweenness.Ranking)
+	}
+	if p.query.IsTopKQuery() {
+		result.Ranking = mergeTopK(rankings, int(p.query.TopK))
+	}
+	return result
+}
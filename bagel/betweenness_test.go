@@ -0,0 +1,153 @@
+package bagel
+
+import (
+	"math"
+	"testing"
+)
+
+// betweennessGraph has two shortest paths from 1 to 4, through 2 and 3, and
+// every path to 5 goes through 4
+var betweennessGraph = map[uint64][]uint64{
+	1: {2, 3},
+	2: {4},
+	3: {4},
+	4: {5},
+	5: {},
+}
+
+func TestBetweennessExact(t *testing.T) {
+	// every vertex is a source when the graph has fewer vertices than
+	// samples
+	w, result := runTestQuery(
+		t, Query{QueryType: BETWEENNESS, Nodes: []uint64{4}}, betweennessGraph,
+	)
+
+	betweenness := result.(BetweennessResult)
+	if betweenness.NumSources != 5 || betweenness.Score != 3 {
+		t.Errorf(
+			"expected betweenness 3 from 5 sources but got %v from %v",
+			betweenness.Score, betweenness.NumSources,
+		)
+	}
+
+	expected := map[uint64]float64{1: 0, 2: 1, 3: 1, 4: 3, 5: 0}
+	for id, score := range expected {
+		value := w.Vertices[id].CurrentValue.(BetweennessVertex)
+		if math.Abs(value.Score-score) > 1e-9 {
+			t.Errorf(
+				"expected betweenness %v for vertex %v but got %v", score, id,
+				value.Score,
+			)
+		}
+	}
+}
+
+func TestBetweennessRanking(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{
+			QueryType: BETWEENNESS, ResultMode: RESULT_TOP_K, TopK: 2,
+		}, betweennessGraph,
+	)
+
+	expected := []RankedVertex{{VertexId: 4, Score: 3}, {VertexId: 2, Score: 1}}
+	betweenness := result.(BetweennessResult)
+	if len(betweenness.Ranking) != len(expected) ||
+		betweenness.NumSources != 5 {
+		t.Fatalf(
+			"expected ranking %v from 5 sources but got %v from %v", expected,
+			betweenness.Ranking, betweenness.NumSources,
+		)
+	}
+	for idx := range expected {
+		if math.Abs(betweenness.Ranking[idx].Score-expected[idx].Score) > 1e-9 ||
+			betweenness.Ranking[idx].VertexId != expected[idx].VertexId {
+			t.Errorf(
+				"expected ranking %v but got %v", expected, betweenness.Ranking,
+			)
+		}
+	}
+}
+
+func TestBetweennessSampled(t *testing.T) {
+	// on the path 1 -> 2 -> ... -> n, the sources before v depend on v for
+	// the n - v vertices after it
+	const numVertices = 30
+	graph := make(map[uint64][]uint64, numVertices)
+	for id := uint64(1); id < numVertices; id++ {
+		graph[id] = []uint64{id + 1}
+	}
+	graph[numVertices] = []uint64{}
+
+	const numSamples = 8
+	w, result := runTestQuery(
+		t, Query{
+			QueryType: BETWEENNESS, Nodes: []uint64{15},
+			Params: map[string]float64{
+				BETWEENNESS_NUM_SAMPLES: numSamples, BETWEENNESS_SEED: 3,
+			},
+		}, graph,
+	)
+
+	betweenness := result.(BetweennessResult)
+	if betweenness.NumSources != numSamples {
+		t.Fatalf(
+			"expected %v sources but got %v", numSamples,
+			betweenness.NumSources,
+		)
+	}
+
+	numSourcesBefore := 0
+	numSources := 0
+	for id := uint64(1); id <= numVertices; id++ {
+		value := w.Vertices[id].CurrentValue.(BetweennessVertex)
+		if !value.IsSource {
+			continue
+		}
+		numSources++
+		if id < 15 {
+			numSourcesBefore++
+		}
+	}
+	if uint64(numSources) != betweenness.NumSources {
+		t.Errorf(
+			"expected %v sources but got %v", numSources,
+			betweenness.NumSources,
+		)
+	}
+	expected := float64(numVertices) / float64(numSources) *
+		float64(numSourcesBefore*(numVertices-15))
+	if math.Abs(betweenness.Score-expected) > 1e-9 {
+		t.Errorf(
+			"expected estimated betweenness %v but got %v", expected,
+			betweenness.Score,
+		)
+	}
+}
+
+func TestBetweennessValidation(t *testing.T) {
+	if _, err := NewVertexProgram(Query{QueryType: BETWEENNESS}); err == nil {
+		t.Errorf("betweenness query without a target vertex is valid")
+	}
+	if _, err := NewVertexProgram(
+		Query{
+			QueryType: BETWEENNESS, Nodes: []uint64{1},
+			ResultMode: RESULT_TOP_K, TopK: 10,
+		},
+	); err == nil {
+		t.Errorf("betweenness ranking of a vertex is valid")
+	}
+	for _, params := range []map[string]float64{
+		{BETWEENNESS_NUM_SAMPLES: 0},
+		{BETWEENNESS_NUM_SAMPLES: 2.5},
+		{BETWEENNESS_NUM_SAMPLES: math.NaN()},
+		{BETWEENNESS_NUM_SAMPLES: math.Inf(1)},
+		{BETWEENNESS_SEED: 0.5},
+		{BETWEENNESS_SEED: math.NaN()},
+	} {
+		if _, err := NewVertexProgram(
+			Query{QueryType: BETWEENNESS, Nodes: []uint64{1}, Params: params},
+		); err == nil {
+			t.Errorf("betweenness query with params %v is valid", params)
+		}
+	}
+}
@@ -80,6 +80,10 @@ func (w *Worker) initializeCheckpoints() error {
 	return nil
 }
 
+// checkpoint shares the vertex values and queued messages rather than copying
+// them. They are gob encoded as an interface{}, so programs register the
+// types of their values, and replace values like the HyperLogLog sketches of
+// HyperANF instead of modifying them
 func (w *Worker) checkpoint(superStepNum uint64) Checkpoint {
 	checkPointState := make(map[uint64]VertexCheckpoint)
 
@@ -87,8 +91,12 @@ func (w *Worker) checkpoint(superStepNum uint64) Checkpoint {
 		checkPointState[k] = VertexCheckpoint{
 			Id:             v.Id,
 			Neighbors:      v.Neighbors,
+			InNeighbors:    v.InNeighbors,
+			EdgeWeights:    v.EdgeWeights,
 			PreviousValues: v.PreviousValues,
 			CurrentValue:   v.CurrentValue,
+			Predecessor:    v.Predecessor,
+			IsActive:       v.IsActive,
 		}
 	}
 
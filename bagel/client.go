@@ -1,7 +1,6 @@
 package bagel
 
 import (
-	"errors"
 	"log"
 	"net"
 	"net/rpc"
@@ -26,17 +25,8 @@ func NewClient() *GraphClient {
 }
 
 func (c *GraphClient) SendQuery(query Query) error {
-	switch query.QueryType {
-	case PAGE_RANK:
-		if len(query.Nodes) != 1 {
-			return errors.New("incorrect number of vertices in the query")
-		}
-	case SHORTEST_PATH:
-		if len(query.Nodes) != 2 {
-			return errors.New("incorrect number of vertices in the query")
-		}
-	default:
-		return errors.New("unknown query type")
+	if _, err := NewVertexProgram(query); err != nil {
+		return err
 	}
 
 	log.Printf("SendQuery: query is queued up to be sent.")
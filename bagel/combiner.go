@@ -0,0 +1,29 @@
+package bagel
+
+// combineMessages merges the messages sent to the same vertex, keeping the
+// order in which the destination vertices first appear. It returns the
+// combined messages along with the number of messages combined away
+func combineMessages(combiner Combiner, messages []Message) ([]Message, int) {
+	combined := make([]Message, 0, len(messages))
+	indexOf := make(map[uint64]int)
+	for _, message := range messages {
+		if idx, exists := indexOf[message.DestVertexId]; exists {
+			combined[idx] = combiner.Combine(combined[idx], message)
+			continue
+		}
+		indexOf[message.DestVertexId] = len(combined)
+		combined = append(combined, message)
+	}
+	return combined, len(messages) - len(combined)
+}
+
+// minMessage returns the message with the smaller numeric value, which keeps
+// the source of the message that updates the receiving vertex
+func minMessage(a Message, b Message) Message {
+	aValue, _ := NumericValue(a.Value)
+	bValue, _ := NumericValue(b.Value)
+	if bValue < aValue {
+		return b
+	}
+	return a
+}
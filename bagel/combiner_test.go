@@ -0,0 +1,46 @@
+package bagel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCombineMessages(t *testing.T) {
+	program := &shortestPathProgram{}
+	messages := []Message{
+		{SourceVertexId: 1, DestVertexId: 3, Value: 4},
+		{SourceVertexId: 2, DestVertexId: 4, Value: 2},
+		{SourceVertexId: 5, DestVertexId: 3, Value: 1},
+		{SourceVertexId: 6, DestVertexId: 3, Value: 1},
+	}
+
+	combined, numCombined := combineMessages(program, messages)
+	expected := []Message{
+		{SourceVertexId: 5, DestVertexId: 3, Value: 1},
+		{SourceVertexId: 2, DestVertexId: 4, Value: 2},
+	}
+	if !reflect.DeepEqual(combined, expected) {
+		t.Errorf("expected messages %v but got %v", expected, combined)
+	}
+	if numCombined != 2 {
+		t.Errorf("expected 2 combined messages but got %v", numCombined)
+	}
+}
+
+func TestQueueMessageCombinesReceivedMessages(t *testing.T) {
+	w := Worker{program: &degreeProgram{}, NextSuperStep: NewSuperStep()}
+	w.queueMessage(Message{SourceVertexId: 1, DestVertexId: 3, Value: 1})
+	w.queueMessage(Message{SourceVertexId: 2, DestVertexId: 3, Value: 2})
+	w.queueMessage(Message{SourceVertexId: 2, DestVertexId: 4, Value: 1})
+
+	if messages := w.NextSuperStep.Messages[3]; len(messages) != 1 ||
+		messages[0].Value != 3 {
+		t.Errorf("expected one message with value 3 but got %v", messages)
+	}
+	if w.NextSuperStep.NumCombined != 1 {
+		t.Errorf(
+			"expected 1 combined message but got %v",
+			w.NextSuperStep.NumCombined,
+		)
+	}
+}
@@ -1,15 +1,53 @@
 package bagel
 
-import "net/rpc"
+import (
+	"math"
+	"net/rpc"
+)
 
 // constants are used as msgType for the messages
 const (
-	PAGE_RANK            = "PageRank"
-	SHORTEST_PATH        = "ShortestPath"
-	SHORTEST_PATH_SOURCE = "ShortestPathSource"
-	SHORTEST_PATH_DEST   = "ShortestPathDestination"
+	PAGE_RANK              = "PageRank"
+	SHORTEST_PATH          = "ShortestPath"
+	WEIGHTED_SHORTEST_PATH = "WeightedShortestPath"
+	SHORTEST_PATH_SOURCE   = "ShortestPathSource"
+	SHORTEST_PATH_DEST     = "ShortestPathDestination"
+	CONNECTED_COMPONENTS   = "ConnectedComponents"
+	DEGREE                 = "Degree"
+	// STRONGLY_CONNECTED_COMPONENTS and TRIANGLE_COUNT need the in-edges
+	// stored with the graph
+	STRONGLY_CONNECTED_COMPONENTS = "StronglyConnectedComponents"
+	TRIANGLE_COUNT                = "TriangleCount"
+	LABEL_PROPAGATION             = "LabelPropagation"
+	PERSONALIZED_PAGE_RANK        = "PersonalizedPageRank"
+	// HITS, K_CORE and GRAPH_COLORING need the in-edges stored with the graph
+	HITS   = "HITS"
+	K_CORE = "KCore"
+	// K_HOP_NEIGHBORHOOD searches from every vertex of the query
+	K_HOP_NEIGHBORHOOD = "KHopNeighborhood"
+	HYPER_ANF          = "HyperANF"
+	GRAPH_COLORING     = "GraphColoring"
+	// MINIMUM_SPANNING_FOREST uses the weights of the edges
+	MINIMUM_SPANNING_FOREST = "MinimumSpanningForest"
+	// RANDOM_WALK writes its walks to a file on every worker
+	RANDOM_WALK = "RandomWalk"
+	// LINK_PREDICTION needs the in-edges stored with the graph
+	LINK_PREDICTION = "LinkPrediction"
+	// BETWEENNESS estimates the betweenness from a sample of the vertices
+	BETWEENNESS = "Betweenness"
+)
+
+// constants are used as the ResultMode of a query
+const (
+	RESULT_TARGET        = "Target"
+	RESULT_VERTEX_VALUES = "VertexValues"
+	RESULT_TOP_K         = "TopK"
 )
 
+// VERTEX_VALUES_BATCH_SIZE is the number of vertex values sent to the client
+// in each message of a streamed VertexValues result
+const VERTEX_VALUES_BATCH_SIZE = 1000
+
 type WorkerNode struct {
 	WorkerConfigId   uint32
 	WorkerLogicalId  uint32
@@ -38,6 +76,8 @@ type ProgressSuperStep struct {
 	SuperStepNum uint64
 	IsCheckpoint bool
 	IsRestart    bool
+	Aggregated   map[string]float64 // aggregator values of the last superstep
+	Master       MasterState        // set by the program's MasterComputer
 }
 
 type VertexMessages map[uint64][]Message
@@ -53,15 +93,51 @@ type ProgressSuperStepResult struct {
 	IsCheckpoint bool
 	IsActive     bool
 	CurrentValue interface{}
+	Aggregates   map[string]float64 // aggregator values of the worker
+	NumSent      int                // messages delivered at the superstep
+	NumReceived  int                // messages computed at the superstep
 	// experimental
 	Messages VertexMessages
 }
 
+type PredecessorRequest struct {
+	VertexId uint64
+}
+
+type PredecessorResult struct {
+	VertexId       uint64
+	Predecessor    uint64
+	HasPredecessor bool
+}
+
+type VertexValuesRequest struct {
+	Nodes []uint64
+}
+
+type VertexValuesResult struct {
+	Values map[uint64]float64
+	// edges of the result vertices if the program is an EdgeReporter
+	Edges []SpanningEdge
+}
+
+type TopKRequest struct {
+	K uint32
+}
+
+type TopKResult struct {
+	Ranking []RankedVertex
+}
+
 type RestartSuperStep struct {
 	SuperStepNumber uint64
 	WorkerDirectory WorkerDirectory
 	NumWorkers      uint8
 	Query           Query
+	// aggregator and master state restored from the checkpoint, along with
+	// the number of messages it queued
+	Aggregated map[string]float64
+	Master     MasterState
+	NumQueued  int
 }
 
 //type UpdateMainReplica struct {
@@ -76,18 +152,84 @@ type CheckpointMsg struct {
 }
 
 type Query struct {
-	ClientId  string
-	QueryType string   // PageRank or ShortestPath
-	Nodes     []uint64 // if PageRank, will have 1 vertex, if shortestpath, will have [start, end]
-	Graph     string   // graph to use - will always be google for now
+	ClientId string
+	// any query type registered with RegisterVertexProgram, such as
+	// PageRank, ShortestPath or LabelPropagation
+	QueryType string
+	// if PageRank or Degree, will have 1 vertex, if shortestpath, will have
+	// [start, end]. if ConnectedComponents, will have 1 vertex, or none to
+	// count components
+	Nodes     []uint64
+	Graph     string // graph to use - will always be google for now
 	TableName string
+	// Target returns the value of the query's target vertex, VertexValues
+	// returns the final value of every vertex in ResultNodes, or of every
+	// vertex if ResultNodes is empty, and TopK returns the TopK vertices with
+	// the highest values. Defaults to Target
+	ResultMode  string
+	ResultNodes []uint64
+	TopK        uint32
+	// algorithm parameters, such as the maximum number of supersteps of a
+	// label propagation query
+	Params map[string]float64
+}
+
+// Param returns the named parameter of the query, or defaultValue if the
+// query does not set it
+func (q Query) Param(name string, defaultValue float64) float64 {
+	if value, exists := q.Params[name]; exists {
+		return value
+	}
+	return defaultValue
+}
+
+// IsIntParam reports whether the named parameter of the query, or
+// defaultValue if the query does not set it, is a whole number in
+// [min, max]. NaN and infinities are not, so that parameters converted to
+// integers are checked with it first
+func (q Query) IsIntParam(
+	name string, defaultValue float64, min float64, max float64,
+) bool {
+	value := q.Param(name, defaultValue)
+	return value >= min && value <= max && value == math.Trunc(value)
+}
+
+// IsTargetQuery reports whether the query returns the value of its target
+// vertex
+func (q Query) IsTargetQuery() bool {
+	return !q.IsVertexValuesQuery() && !q.IsTopKQuery()
+}
+
+// IsVertexValuesQuery reports whether the query returns the value of every
+// result vertex rather than the value of its target vertex
+func (q Query) IsVertexValuesQuery() bool {
+	return q.ResultMode == RESULT_VERTEX_VALUES
+}
+
+// IsTopKQuery reports whether the query returns a ranking of the vertices
+// with the highest values
+func (q Query) IsTopKQuery() bool {
+	return q.ResultMode == RESULT_TOP_K
 }
 
 type QueryResult struct {
 	Query  Query
 	Result interface{} // client dynamically casts Result based on Query.QueryType:
 	Error  string
-	// float64 for pagerank, int for shortest path
+	// float64 for pagerank and weighted shortest path, int for shortest path
+	// and degree, uint64 component id or int component count for connected
+	// components, ComponentResult or ComponentHistogram for strongly
+	// connected components, float64 clustering coefficient or int triangle
+	// count for triangle counting, ComponentResult or ComponentHistogram for
+	// label propagation, HITSScores for hits, CoreResult for k-core, int
+	// number of reachable vertices for k-hop neighborhoods,
+	// NeighborhoodFunction for hyperanf, ColoringResult for graph coloring,
+	// SpanningForest for minimum spanning forests, int number of walks for
+	// random walks, BetweennessResult for betweenness
+	// vertices from source to destination for shortest paths
+	Path []uint64
+	// best ranked vertices first for TopK queries
+	Ranking []RankedVertex
 }
 
 type EndQuery struct {
@@ -0,0 +1,109 @@
+package bagel
+
+import (
+	"encoding/gob"
+	coordgRPC "project/bagel/proto/coord"
+)
+
+// ComponentResult is the component, or community, of a queried vertex
+type ComponentResult struct {
+	ComponentId uint64
+	Size        uint64
+}
+
+// FillQueryResult sends the id of the component as the Result, along with
+// its size
+func (r ComponentResult) FillQueryResult(reply *coordgRPC.QueryResult) {
+	reply.Result = float64(r.ComponentId)
+	reply.ComponentSize = r.Size
+}
+
+// ComponentHistogram maps a component size to the number of components of
+// that size
+type ComponentHistogram map[uint64]uint64
+
+// FillQueryResult sends the number of components as the Result, along with
+// the histogram
+func (h ComponentHistogram) FillQueryResult(reply *coordgRPC.QueryResult) {
+	reply.SizeHistogram = h
+	for _, numComponents := range h {
+		reply.Result += float64(numComponents)
+	}
+}
+
+// componentSizesPartial is a worker's share of the result of a query whose
+// vertex values are component ids: the number of its vertices in each
+// component, and the component of the queried vertex if it is on the worker
+type componentSizesPartial struct {
+	Sizes            map[uint64]uint64
+	QueriedComponent uint64
+	HasQueried       bool
+}
+
+func init() {
+	// partial results are sent to the coord as an interface{}
+	gob.Register(componentSizesPartial{})
+}
+
+// partialComponentSizes is the PartialResult of programs whose vertex values
+// are component ids
+func partialComponentSizes(
+	query Query, vertices map[uint64]*Vertex,
+) interface{} {
+	partial := componentSizesPartial{Sizes: make(map[uint64]uint64)}
+	for _, vertex := range vertices {
+		partial.Sizes[vertex.CurrentValue.(uint64)]++
+	}
+	if len(query.Nodes) == 1 {
+		if vertex, exists := vertices[query.Nodes[0]]; exists {
+			partial.QueriedComponent = vertex.CurrentValue.(uint64)
+			partial.HasQueried = true
+		}
+	}
+	return partial
+}
+
+// mergeComponentSizes is the MergeResults of programs whose vertex values are
+// component ids. It returns the ComponentResult of the queried vertex, or the
+// ComponentHistogram of the graph if no vertex is queried. TopK queries rank
+// the components by their number of vertices
+func mergeComponentSizes(query Query, partials []interface{}) interface{} {
+	sizes := make(map[uint64]uint64)
+	queried := ComponentResult{}
+	hasQueried := false
+	for _, partial := range partials {
+		componentPartial := partial.(componentSizesPartial)
+		for componentId, size := range componentPartial.Sizes {
+			sizes[componentId] += size
+		}
+		if componentPartial.HasQueried {
+			queried.ComponentId = componentPartial.QueriedComponent
+			hasQueried = true
+		}
+	}
+
+	if query.IsTopKQuery() {
+		candidates := make([]RankedVertex, 0, len(sizes))
+		for componentId, size := range sizes {
+			candidates = append(
+				candidates,
+				RankedVertex{VertexId: componentId, Score: float64(size)},
+			)
+		}
+		return topK(candidates, int(query.TopK))
+	}
+
+	if len(query.Nodes) == 1 {
+		if !hasQueried {
+			return nil
+		}
+		queried.Size = sizes[queried.ComponentId]
+		return queried
+	}
+
+	histogram := make(ComponentHistogram)
+	for _, size := range sizes {
+		histogram[size]++
+	}
+	return histogram
+}
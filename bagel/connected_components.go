@@ -0,0 +1,183 @@
+package bagel
+
+import (
+	"encoding/gob"
+	"errors"
+)
+
+// connectedComponentsProgram finds weakly connected components with HashMin:
+// every vertex starts labelled with its own id and repeatedly adopts the
+// smallest label seen from a neighbor until no label changes. The smallest
+// vertex id of a component becomes the component id. TopK queries rank the
+// components by their number of vertices. There is no Combiner since the
+// sources of the messages are how vertices learn their in-neighbors
+type connectedComponentsProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(CONNECTED_COMPONENTS, newConnectedComponentsProgram)
+
+	// partial results of TopK queries are sent to the coord as an interface{}
+	gob.Register(map[uint64]int{})
+}
+
+func newConnectedComponentsProgram(query Query) VertexProgram {
+	return &connectedComponentsProgram{query: query}
+}
+
+func (p *connectedComponentsProgram) Validate() error {
+	if len(p.query.Nodes) > 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	return nil
+}
+
+func (p *connectedComponentsProgram) InitialValue(v *Vertex) interface{} {
+	return v.Id
+}
+
+// InitialMessages wakes up every vertex at superstep 1 so it can send its
+// label to its neighbors
+func (p *connectedComponentsProgram) InitialMessages(v *Vertex) []Message {
+	return []Message{{INITIALIZATION_VERTEX, v.Id, v.Id}}
+}
+
+func (p *connectedComponentsProgram) Compute(v *Vertex) []Message {
+	currentLabel := v.CurrentValue.(uint64)
+	minLabel := currentLabel
+	isFirstSuperStep := false
+	newInNeighbors := make([]uint64, 0)
+
+	for _, message := range v.Messages {
+		label := message.Value.(uint64)
+		if message.SourceVertexId == INITIALIZATION_VERTEX {
+			isFirstSuperStep = true
+		} else {
+			// vertices only store out-edges, so remember the vertices that
+			// message us to propagate labels against the edge direction
+			if _, exists := v.PreviousValues[message.SourceVertexId]; !exists {
+				newInNeighbors = append(newInNeighbors, message.SourceVertexId)
+			}
+			v.PreviousValues[message.SourceVertexId] = label
+		}
+
+		if label < minLabel {
+			minLabel = label
+		}
+	}
+	v.CurrentValue = minLabel
+
+	// a vertex with an unchanged label only needs to tell the neighbors it
+	// has just discovered
+	receivers := newInNeighbors
+	if isFirstSuperStep || minLabel < currentLabel {
+		receivers = p.undirectedNeighbors(v)
+	}
+
+	result := make([]Message, 0, len(receivers))
+	for _, neighborVertexId := range receivers {
+		if neighborVertexId == v.Id {
+			continue
+		}
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          minLabel,
+			},
+		)
+	}
+
+	// the label only changes when a neighbor sends a smaller one
+	if len(result) == 0 {
+		v.VoteToHalt()
+	}
+	return result
+}
+
+// undirectedNeighbors returns the out-neighbors of the vertex along with the
+// in-neighbors it has discovered so far
+func (p *connectedComponentsProgram) undirectedNeighbors(v *Vertex) []uint64 {
+	neighbors := make([]uint64, 0, len(v.Neighbors)+len(v.PreviousValues))
+	seen := make(map[uint64]bool, cap(neighbors))
+	for _, neighborVertexId := range v.Neighbors {
+		if !seen[neighborVertexId] {
+			seen[neighborVertexId] = true
+			neighbors = append(neighbors, neighborVertexId)
+		}
+	}
+	for neighborVertexId := range v.PreviousValues {
+		if !seen[neighborVertexId] {
+			seen[neighborVertexId] = true
+			neighbors = append(neighbors, neighborVertexId)
+		}
+	}
+	return neighbors
+}
+
+// PartialResult returns the component id of the queried vertex if it is on
+// this worker, or otherwise the number of components whose id is held by one
+// of the worker's vertices. For TopK queries, it returns the number of the
+// worker's vertices in each component
+func (p *connectedComponentsProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	if p.query.IsTopKQuery() {
+		componentSizes := make(map[uint64]int)
+		for _, vertex := range vertices {
+			componentSizes[vertex.CurrentValue.(uint64)]++
+		}
+		return componentSizes
+	}
+
+	if len(p.query.Nodes) == 1 {
+		if vertex, exists := vertices[p.query.Nodes[0]]; exists {
+			return vertex.CurrentValue
+		}
+		return nil
+	}
+
+	numComponents := 0
+	for _, vertex := range vertices {
+		if vertex.CurrentValue.(uint64) == vertex.Id {
+			numComponents++
+		}
+	}
+	return numComponents
+}
+
+func (p *connectedComponentsProgram) MergeResults(
+	partials []interface{},
+) interface{} {
+	if p.query.IsTopKQuery() {
+		componentSizes := make(map[uint64]int)
+		for _, partial := range partials {
+			for componentId, size := range partial.(map[uint64]int) {
+				componentSizes[componentId] += size
+			}
+		}
+
+		candidates := make([]RankedVertex, 0, len(componentSizes))
+		for componentId, size := range componentSizes {
+			candidates = append(
+				candidates,
+				RankedVertex{VertexId: componentId, Score: float64(size)},
+			)
+		}
+		return topK(candidates, int(p.query.TopK))
+	}
+
+	if len(p.query.Nodes) == 1 {
+		if len(partials) == 0 {
+			return nil
+		}
+		return partials[0]
+	}
+
+	numComponents := 0
+	for _, partial := range partials {
+		numComponents += partial.(int)
+	}
+	return numComponents
+}
@@ -0,0 +1,65 @@
+package bagel
+
+import (
+	"reflect"
+	"testing"
+)
+
+// two weakly connected components, {1, 2, 3, 6} and {4, 5}, whose edges
+// only connect them when followed in both directions
+var testComponentsGraph = map[uint64][]uint64{
+	1: {2},
+	2: {},
+	3: {2},
+	4: {},
+	5: {4},
+	6: {3},
+}
+
+func TestConnectedComponentsLabels(t *testing.T) {
+	w, _ := runTestQuery(
+		t, Query{QueryType: CONNECTED_COMPONENTS}, testComponentsGraph,
+	)
+
+	expected := map[uint64]uint64{1: 1, 2: 1, 3: 1, 4: 4, 5: 4, 6: 1}
+	for id, label := range expected {
+		if w.Vertices[id].CurrentValue != label {
+			t.Errorf(
+				"vertex %v: expected component %v but got %v", id, label,
+				w.Vertices[id].CurrentValue,
+			)
+		}
+	}
+}
+
+func TestConnectedComponentsCount(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{QueryType: CONNECTED_COMPONENTS}, testComponentsGraph,
+	)
+	if result != 2 {
+		t.Errorf("expected 2 components but got %v", result)
+	}
+}
+
+func TestConnectedComponentsOfVertex(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{QueryType: CONNECTED_COMPONENTS, Nodes: []uint64{6}},
+		testComponentsGraph,
+	)
+	if result != uint64(1) {
+		t.Errorf("expected component 1 but got %v", result)
+	}
+}
+
+func TestConnectedComponentsTopK(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{
+			QueryType: CONNECTED_COMPONENTS, ResultMode: RESULT_TOP_K, TopK: 1,
+		}, testComponentsGraph,
+	)
+
+	expected := []RankedVertex{{VertexId: 1, Score: 4}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected ranking %v but got %v", expected, result)
+	}
+}
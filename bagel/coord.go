@@ -39,6 +39,111 @@ const (
 func (c *Coord) StartQuery(ctx context.Context, q *coordgRPC.Query) (
 	*coordgRPC.QueryResult,
 	error,
+) {
+	reply, _ := c.runQuery(q)
+
+	// return nil for no errors
+	return reply, nil
+}
+
+// StreamVertexValues runs the query in the VertexValues result mode and
+// streams the final vertex values back to the client in batches
+func (c *Coord) StreamVertexValues(
+	q *coordgRPC.Query, stream coordgRPC.Coord_StreamVertexValuesServer,
+) error {
+	q.ResultMode = coordgRPC.RESULT_MODE_VERTEX_VALUES
+	reply, vertexValues := c.runQuery(q)
+	values := vertexValues.Values
+	if reply.Error != "" {
+		return stream.Send(&coordgRPC.VertexValuesResponse{Error: reply.Error})
+	}
+
+	// send the values in order of vertex id
+	vertexIds := make([]uint64, 0, len(values))
+	for vertexId := range values {
+		vertexIds = append(vertexIds, vertexId)
+	}
+	sort.Slice(
+		vertexIds, func(i, j int) bool {
+			return vertexIds[i] < vertexIds[j]
+		},
+	)
+
+	for start := 0; start < len(vertexIds); start += VERTEX_VALUES_BATCH_SIZE {
+		end := start + VERTEX_VALUES_BATCH_SIZE
+		if end > len(vertexIds) {
+			end = len(vertexIds)
+		}
+
+		batch := make([]*coordgRPC.VertexValue, 0, end-start)
+		for _, vertexId := range vertexIds[start:end] {
+			batch = append(
+				batch, &coordgRPC.VertexValue{
+					VertexId: vertexId,
+					Value:    values[vertexId],
+				},
+			)
+		}
+		err := stream.Send(
+			&coordgRPC.VertexValuesResponse{VertexValues: batch},
+		)
+		if err != nil {
+			log.Printf("StreamVertexValues: error sending batch: %v\n", err)
+			return err
+		}
+	}
+	if err := c.streamEdges(vertexValues.Edges, stream); err != nil {
+		log.Printf("StreamVertexValues: error sending edges: %v\n", err)
+		return err
+	}
+	log.Printf(
+		"StreamVertexValues: sent %v vertex values and %v edges\n",
+		len(vertexIds), len(vertexValues.Edges),
+	)
+	return nil
+}
+
+// streamEdges sends the result edges of the query to the client in batches,
+// in order of source and dest
+func (c *Coord) streamEdges(
+	edges []SpanningEdge, stream coordgRPC.Coord_StreamVertexValuesServer,
+) error {
+	sort.Slice(
+		edges, func(i, j int) bool {
+			if edges[i].Source != edges[j].Source {
+				return edges[i].Source < edges[j].Source
+			}
+			return edges[i].Dest < edges[j].Dest
+		},
+	)
+
+	for start := 0; start < len(edges); start += VERTEX_VALUES_BATCH_SIZE {
+		end := start + VERTEX_VALUES_BATCH_SIZE
+		if end > len(edges) {
+			end = len(edges)
+		}
+
+		batch := make([]*coordgRPC.ResultEdge, 0, end-start)
+		for _, edge := range edges[start:end] {
+			batch = append(
+				batch, &coordgRPC.ResultEdge{
+					Source: edge.Source, Dest: edge.Dest, Weight: edge.Weight,
+				},
+			)
+		}
+		err := stream.Send(&coordgRPC.VertexValuesResponse{Edges: batch})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runQuery computes the query on the workers and returns its result, along
+// with the vertex values and edges gathered for VertexValues queries
+func (c *Coord) runQuery(q *coordgRPC.Query) (
+	*coordgRPC.QueryResult,
+	VertexValuesResult,
 ) {
 	var reply coordgRPC.QueryResult
 
@@ -56,6 +161,25 @@ func (c *Coord) StartQuery(ctx context.Context, q *coordgRPC.Query) (
 		// block while no workers available
 	}
 
+	coordQuery := Query{
+		ClientId:    q.ClientId,
+		QueryType:   queryTypeFromProto(q),
+		Nodes:       q.Nodes,
+		Graph:       q.Graph,
+		TableName:   q.TableName,
+		ResultMode:  resultModeFromProto(q.ResultMode),
+		ResultNodes: q.ResultNodes,
+		TopK:        q.TopK,
+		Params:      q.Params,
+	}
+
+	// validate the query against the vertex program that will run it
+	program, err := NewVertexProgram(coordQuery)
+	if err != nil {
+		reply.Error = err.Error()
+		return &reply, VertexValuesResult{}
+	}
+
 	// validate vertices sent by the client query
 	client := mongodb.GetDatabaseClient()
 	collection := mongodb.GetCollection(client, q.TableName)
@@ -63,7 +187,7 @@ func (c *Coord) StartQuery(ctx context.Context, q *coordgRPC.Query) (
 		vertex, err := mongodb.GetVertexById(collection, vId)
 		if err != nil {
 			reply.Error = err.Error()
-			return &reply, nil
+			return &reply, VertexValuesResult{}
 		}
 		log.Printf("coord fetched query vertex %v\n", vertex)
 	}
@@ -88,21 +212,6 @@ func (c *Coord) StartQuery(ctx context.Context, q *coordgRPC.Query) (
 	c.lastWorkerCheckpoints = make(map[uint32]uint64)
 	c.superStepNumber = 1
 
-	coordQueryType := ""
-	switch q.QueryType {
-	case coordgRPC.QUERY_TYPE_PAGE_RANK:
-		coordQueryType = PAGE_RANK
-	case coordgRPC.QUERY_TYPE_SHORTEST_PATH:
-		coordQueryType = SHORTEST_PATH
-	}
-
-	coordQuery := Query{
-		ClientId:  q.ClientId,
-		QueryType: coordQueryType,
-		Nodes:     q.Nodes,
-		Graph:     q.Graph,
-		TableName: q.TableName,
-	}
 	log.Printf("StartQuery: sending query: %v\n", coordQuery)
 
 	startSuperStep := StartSuperStep{
@@ -123,6 +232,7 @@ func (c *Coord) StartQuery(ctx context.Context, q *coordgRPC.Query) (
 	c.fetchGraphDone = make(chan WorkerVertices, 1)
 
 	c.query = coordQuery
+	c.program = program
 
 	log.Printf(
 		"StartQuery: computing query %v with %d workers ready!\n", q,
@@ -172,24 +282,55 @@ func (c *Coord) StartQuery(ctx context.Context, q *coordgRPC.Query) (
 	//note: we cannot convert interface{} to float64,
 	//need to identify the runtime type of interface{} first)
 	log.Printf("type of result: %T\n", result)
-	switch resultType := result.(type) {
-	case float64:
-		reply.Result = resultType
-	case int:
-		reply.Result = float64(resultType)
-		//default:
-		//	reply.Result = float64(resultType)
+	switch value := result.(type) {
+	case QueryResultFiller:
+		value.FillQueryResult(&reply)
+	default:
+		reply.Result, _ = NumericValue(result)
 	}
 
-	log.Printf("StartQuery: sending back result: %v\n", reply.Result)
+	reply.Path = c.queryPath
+	reply.Ranking = rankingReply(c.ranking)
+	vertexValues := c.vertexValues
+
+	log.Printf(
+		"StartQuery: sending back result: %v, path: %v\n", reply.Result,
+		reply.Path,
+	)
 
 	c.queryWorkers = nil
 	c.queryReplicas = nil
 	c.queryWorkersCallbook = nil
 	c.query = Query{}
+	c.program = nil
+	c.queryPath = nil
+	c.vertexValues = VertexValuesResult{}
+	c.ranking = nil
+	c.masterState = MasterState{}
+	c.messagesInFlight = 0
+
+	return &reply, vertexValues
+}
 
-	// return nil for no errors
-	return &reply, nil
+// queryTypeFromProto returns the query type of the vertex program that runs
+// the gRPC query: its Algorithm when set, or else the registered program
+// named like its QueryType
+func queryTypeFromProto(q *coordgRPC.Query) string {
+	if q.Algorithm != "" {
+		return q.Algorithm
+	}
+	queryType, _ := registeredQueryType(q.QueryType.String())
+	return queryType
+}
+
+func resultModeFromProto(resultMode coordgRPC.RESULT_MODE) string {
+	switch resultMode {
+	case coordgRPC.RESULT_MODE_VERTEX_VALUES:
+		return RESULT_VERTEX_VALUES
+	case coordgRPC.RESULT_MODE_TOP_K:
+		return RESULT_TOP_K
+	}
+	return RESULT_TARGET
 }
 
 func (c *Coord) FetchGraph(
@@ -321,6 +462,12 @@ type Coord struct {
 	allWorkersReady       chan superstepDone
 	restartSuperStepCh    chan uint32
 	query                 Query
+	program               VertexProgram
+	queryPath             []uint64
+	vertexValues          VertexValuesResult
+	ranking               []RankedVertex
+	masterState           MasterState
+	messagesInFlight      int
 	workerReadyMap        map[uint32]bool
 	workerReadyMapMutex   sync.Mutex
 	mx                    sync.Mutex
@@ -334,6 +481,11 @@ type superstepDone struct {
 	isSuccess          bool
 	value              interface{}
 	isRestart          bool
+	aggregated         map[string]float64
+	master             MasterState // restored master state of a restart
+	numSent            int
+	numReceived        int
+	numQueued          int // messages restored by a restart
 	// experimental
 	messages VertexMessages
 	// experimental
@@ -679,6 +831,11 @@ func (c *Coord) blockWorkersReady(
 	readyWorkerCounter := 0
 	inactiveWorkerCounter := 0
 	var computeResult interface{} // result from a single superstep
+	partialResults := make([]interface{}, 0, numWorkers)
+	partialAggregates := make([]map[string]float64, 0, numWorkers)
+	var restartAggregated map[string]float64
+	var restartMaster MasterState
+	numSent, numReceived, numQueued := 0, 0, 0
 	superstepMessages := make(VertexMessages)
 	workerVertices := make(WorkerVertices)
 	var workerVerticesMutex sync.Mutex
@@ -699,8 +856,17 @@ func (c *Coord) blockWorkersReady(
 					// set the value returned from the worker
 					if ssComplete.CurrentValue != nil {
 						computeResult = ssComplete.CurrentValue
+						partialResults = append(
+							partialResults, ssComplete.CurrentValue,
+						)
 					}
 
+					partialAggregates = append(
+						partialAggregates, ssComplete.Aggregates,
+					)
+					numSent += ssComplete.NumSent
+					numReceived += ssComplete.NumReceived
+
 					// add worker's vertex messages to the messages collection
 					for vId, messages := range ssComplete.Messages {
 						superstepMessages[vId] = messages
@@ -734,8 +900,11 @@ func (c *Coord) blockWorkersReady(
 				isRestart := false
 
 				// set isRestart to true if this is a recovery superstep
-				if _, ok := call.Reply.(*RestartSuperStep); ok {
+				if restart, ok := call.Reply.(*RestartSuperStep); ok {
 					isRestart = true
+					restartAggregated = restart.Aggregated
+					restartMaster = restart.Master
+					numQueued += restart.NumQueued
 				}
 
 				log.Printf(
@@ -747,11 +916,31 @@ func (c *Coord) blockWorkersReady(
 				)
 
 				if readyWorkerCounter == numWorkers {
+					// merge the shares of programs with a graph-wide result
+					merger, isMerger := c.program.(ResultMerger)
+					if isMerger && isComputeComplete {
+						computeResult = merger.MergeResults(partialResults)
+					}
+
+					// the aggregators of a recovery superstep resume from
+					// the checkpoint
+					aggregated := reduceAggregates(
+						aggregatorsOf(c.program), partialAggregates,
+					)
+					if isRestart {
+						aggregated = restartAggregated
+					}
+
 					c.allWorkersReady <- superstepDone{
 						allWorkersInactive: isComputeComplete,
 						isSuccess:          true,
 						value:              computeResult,
 						isRestart:          isRestart,
+						aggregated:         aggregated,
+						master:             restartMaster,
+						numSent:            numSent,
+						numReceived:        numReceived,
+						numQueued:          numQueued,
 						messages:           superstepMessages,
 						workerVertices:     workerVertices,
 					}
@@ -862,6 +1051,12 @@ func (c *Coord) endQuery(params EndQuery) {
 	}
 }
 
+// isQueryDone returns true if every vertex has voted to halt after a
+// superstep and there are no messages left to reactivate them
+func (c *Coord) isQueryDone(result superstepDone) bool {
+	return result.allWorkersInactive && c.messagesInFlight == 0
+}
+
 func (c *Coord) Compute(logger *log.Logger) (interface{}, error) {
 	// keep sending messages to workers, until everything has completed
 	// need to make it concurrent; so put in separate channel
@@ -889,9 +1084,24 @@ func (c *Coord) Compute(logger *log.Logger) (interface{}, error) {
 				c.fetchGraphDone <- result.workerVertices
 			}
 
+			// a recovery superstep resumes the messages and the master
+			// state of the checkpoint, otherwise the program's
+			// MasterComputer decides the state of the next superstep
+			if result.isRestart {
+				c.messagesInFlight = result.numQueued
+				c.masterState = result.master
+			} else {
+				c.messagesInFlight += result.numSent - result.numReceived
+				c.masterState = c.masterCompute(
+					c.superStepNumber-1, result, logger,
+				)
+			}
+
 			// here the messages of for a given superstep for all vertices
-			// is collected
-			if result.allWorkersInactive {
+			// is collected. The query is done once every vertex has voted
+			// to halt and no messages are in flight, unless a new phase
+			// starts
+			if c.isQueryDone(result) && !c.masterState.IsNewPhase {
 				log.Printf(
 					"Compute: complete with result %v!\n",
 					result.value,
@@ -908,6 +1118,36 @@ func (c *Coord) Compute(logger *log.Logger) (interface{}, error) {
 				//	messages: result.messages,
 				//}
 
+				// reconstruct the path while the workers still hold the
+				// vertices of the query
+				finder, isPathFinder := c.program.(PathFinder)
+				if isPathFinder && c.query.IsTargetQuery() {
+					c.queryPath = c.findPath(finder)
+					logger.Printf("Found path %v\n", c.queryPath)
+				}
+
+				if c.query.IsVertexValuesQuery() {
+					c.vertexValues = c.collectVertexValues()
+					logger.Printf(
+						"Collected %v vertex values and %v edges\n",
+						len(c.vertexValues.Values), len(c.vertexValues.Edges),
+					)
+				}
+
+				if c.query.IsTopKQuery() {
+					// programs merging their own result already ranked it,
+					// some along with more than the ranking
+					ranking, isRanking := result.value.([]RankedVertex)
+					if ranked, isRanked := result.value.(RankedResult); isRanked {
+						ranking, isRanking = ranked.Ranked(), true
+					}
+					if !isRanking {
+						ranking = c.collectTopK()
+					}
+					c.ranking = ranking
+					logger.Printf("Ranked top %v: %v\n", c.query.TopK, ranking)
+				}
+
 				// TODO RPC to instruct all workers that the computation
 				// finished
 				endQuery := EndQuery{}
@@ -931,6 +1171,14 @@ func (c *Coord) Compute(logger *log.Logger) (interface{}, error) {
 				SuperStepNum: c.superStepNumber,
 				IsCheckpoint: shouldCheckPoint,
 				IsRestart:    result.isRestart,
+				Aggregated:   result.aggregated,
+				Master:       c.masterState,
+			}
+			if len(result.aggregated) > 0 {
+				logger.Printf(
+					"Aggregated values before superstep %v: %v\n",
+					c.superStepNumber, result.aggregated,
+				)
 			}
 			log.Printf(
 				"Compute: progressing super step # %d, "+
@@ -967,6 +1215,130 @@ func (c *Coord) Compute(logger *log.Logger) (interface{}, error) {
 	}
 }
 
+// collectVertexValues gathers the final values and result edges of the
+// query's result vertices from every query worker
+func (c *Coord) collectVertexValues() VertexValuesResult {
+	numWorkers := len(c.queryWorkersCallbook)
+	workerDoneCh := make(chan *rpc.Call, numWorkers)
+	req := VertexValuesRequest{Nodes: c.query.ResultNodes}
+
+	for wId, wClient := range c.queryWorkersCallbook {
+		var result VertexValuesResult
+		wClient.Go("Worker.GetVertexValues", req, &result, workerDoneCh)
+		log.Printf(
+			"collectVertexValues: called GetVertexValues on worker %v\n", wId,
+		)
+	}
+
+	vertexValues := VertexValuesResult{Values: make(map[uint64]float64)}
+	for i := 0; i < numWorkers; i++ {
+		call := <-workerDoneCh
+		if call.Error != nil {
+			log.Printf(
+				"collectVertexValues: error fetching vertex values: %v\n",
+				call.Error,
+			)
+			continue
+		}
+		result := call.Reply.(*VertexValuesResult)
+		for vertexId, value := range result.Values {
+			vertexValues.Values[vertexId] = value
+		}
+		vertexValues.Edges = append(vertexValues.Edges, result.Edges...)
+	}
+	return vertexValues
+}
+
+// collectTopK merges the local rankings of every query worker into the
+// global ranking of the query
+func (c *Coord) collectTopK() []RankedVertex {
+	numWorkers := len(c.queryWorkersCallbook)
+	workerDoneCh := make(chan *rpc.Call, numWorkers)
+	req := TopKRequest{K: c.query.TopK}
+
+	for wId, wClient := range c.queryWorkersCallbook {
+		var result TopKResult
+		wClient.Go("Worker.GetTopK", req, &result, workerDoneCh)
+		log.Printf("collectTopK: called GetTopK on worker %v\n", wId)
+	}
+
+	rankings := make([][]RankedVertex, 0, numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		call := <-workerDoneCh
+		if call.Error != nil {
+			log.Printf(
+				"collectTopK: error fetching worker ranking: %v\n", call.Error,
+			)
+			continue
+		}
+		rankings = append(rankings, call.Reply.(*TopKResult).Ranking)
+	}
+	return mergeTopK(rankings, int(c.query.TopK))
+}
+
+// rankingReply converts a ranking to the RankedVertex messages of a
+// QueryResult
+func rankingReply(ranking []RankedVertex) []*coordgRPC.RankedVertex {
+	var result []*coordgRPC.RankedVertex
+	for _, rankedVertex := range ranking {
+		result = append(
+			result, &coordgRPC.RankedVertex{
+				VertexId: rankedVertex.VertexId,
+				Score:    rankedVertex.Score,
+			},
+		)
+	}
+	return result
+}
+
+// findPath walks the predecessors of the vertices back from the destination
+// of the query to its source, and returns the path from source to
+// destination. It returns nil if the destination was not reached
+func (c *Coord) findPath(finder PathFinder) []uint64 {
+	source, dest := finder.PathEnds()
+	numWorkers := uint32(len(c.queryWorkers))
+
+	path := []uint64{dest}
+	visited := map[uint64]bool{dest: true}
+	vertexId := dest
+	for vertexId != source {
+		workerId := workerIdForVertex(vertexId, numWorkers)
+		worker, exists := c.queryWorkersCallbook[workerId]
+		if !exists {
+			log.Printf(
+				"findPath: no worker %v for vertex %v\n", workerId, vertexId,
+			)
+			return nil
+		}
+
+		var result PredecessorResult
+		err := worker.Call(
+			"Worker.GetPredecessor", PredecessorRequest{VertexId: vertexId},
+			&result,
+		)
+		if err != nil {
+			log.Printf(
+				"findPath: error fetching predecessor of vertex %v: %v\n",
+				vertexId, err,
+			)
+			return nil
+		}
+		if !result.HasPredecessor || visited[result.Predecessor] {
+			return nil
+		}
+
+		vertexId = result.Predecessor
+		visited[vertexId] = true
+		path = append(path, vertexId)
+	}
+
+	// the path was built from the destination back to the source
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
 func (c *Coord) restartCheckpoint() {
 	log.Printf("restart checkpoint with %v\n", c.lastCheckpointNumber)
 	checkpointNumber := c.lastCheckpointNumber
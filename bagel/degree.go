@@ -0,0 +1,77 @@
+package bagel
+
+import "errors"
+
+// degreeProgram counts the edges of every vertex in either direction. Vertices
+// only store their out-edges, so each vertex tells its out-neighbors about
+// the edge in superstep 1 and adds up the messages it receives in superstep 2
+type degreeProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(DEGREE, newDegreeProgram)
+}
+
+func newDegreeProgram(query Query) VertexProgram {
+	return &degreeProgram{query: query}
+}
+
+func (p *degreeProgram) Validate() error {
+	if p.query.IsTargetQuery() && len(p.query.Nodes) != 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	return nil
+}
+
+func (p *degreeProgram) InitialValue(v *Vertex) interface{} {
+	return 0
+}
+
+func (p *degreeProgram) InitialMessages(v *Vertex) []Message {
+	return []Message{{INITIALIZATION_VERTEX, v.Id, 0}}
+}
+
+func (p *degreeProgram) Compute(v *Vertex) []Message {
+	result := make([]Message, 0)
+	degree := v.CurrentValue.(int)
+	for _, message := range v.Messages {
+		if message.SourceVertexId != INITIALIZATION_VERTEX {
+			// combined messages count more than one edge
+			degree += message.Value.(int)
+			continue
+		}
+
+		degree += len(v.Neighbors)
+		for _, neighborVertexId := range v.Neighbors {
+			result = append(
+				result, Message{
+					SourceVertexId: v.Id,
+					DestVertexId:   neighborVertexId,
+					Value:          1,
+				},
+			)
+		}
+	}
+	v.CurrentValue = degree
+
+	// the degree only changes when the vertex is told about an in-edge
+	if len(result) == 0 {
+		v.VoteToHalt()
+	}
+	return result
+}
+
+// Combine adds up the edges counted by the messages
+func (p *degreeProgram) Combine(a Message, b Message) Message {
+	return Message{
+		SourceVertexId: a.SourceVertexId,
+		DestVertexId:   a.DestVertexId,
+		Value:          a.Value.(int) + b.Value.(int),
+	}
+}
+
+func (p *degreeProgram) Result(v *Vertex) (interface{}, bool) {
+	isTarget := len(p.query.Nodes) == 1 && v.Id == p.query.Nodes[0]
+	return v.CurrentValue, isTarget
+}
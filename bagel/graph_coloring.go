@@ -0,0 +1,237 @@
+package bagel
+
+import (
+	"encoding/gob"
+	"errors"
+	coordgRPC "project/bagel/proto/coord"
+)
+
+// ColoringResult is the result of a graph coloring query: the color of the
+// queried vertex, and the number of colors of the graph
+type ColoringResult struct {
+	Color     uint64
+	NumColors uint64
+}
+
+// FillQueryResult sends the color as the Result, along with the number of
+// colors
+func (r ColoringResult) FillQueryResult(reply *coordgRPC.QueryResult) {
+	reply.Result = float64(r.Color)
+	reply.NumColors = r.NumColors
+}
+
+// coloringPartial is a worker's share of the result of a graph coloring
+// query: the number of colors of its vertices, and the color of the queried
+// vertex if it is on the worker
+type coloringPartial struct {
+	NumColors    uint64
+	QueriedColor uint64
+	HasQueried   bool
+}
+
+// phases of a graph coloring query, which alternate once per round from
+// superstep 1 on
+const (
+	// every uncolored vertex draws a priority for the round and sends it to
+	// its neighbors
+	COLORING_PRIORITY = "Priority"
+	// every uncolored vertex with a higher priority than all its uncolored
+	// neighbors takes the smallest color none of its neighbors has
+	COLORING_COLOR = "Color"
+)
+
+// aggregator and broadcast value of a graph coloring query
+const (
+	// number of vertices left uncolored by a round
+	COLORING_UNCOLORED = "ColoringUncolored"
+	// round of the query, which the priorities of the vertices depend on
+	COLORING_ROUND = "ColoringRound"
+)
+
+// coloringPriority is the priority of an uncolored vertex for a round, sent
+// to its neighbors. Colors are sent as a uint64
+type coloringPriority struct {
+	Priority uint64
+}
+
+// graphColoringProgram colors the vertices with the Jones-Plassmann
+// algorithm, ignoring the direction of the edges. Every round, each
+// uncolored vertex draws a random priority, the hash of its id and the
+// round, and sends it to its neighbors. The vertices with a higher priority
+// than all their uncolored neighbors then take the smallest color none of
+// their neighbors has, and send it to their neighbors. Those vertices are
+// never neighbors, so they can be colored at the same superstep. Drawing new
+// priorities every round keeps the number of rounds low, but every uncolored
+// vertex has to be computed at each phase, which the MasterComputer does by
+// starting the phases. Colors are numbered from 0, and vertices have no
+// value until they are colored. The received colors are kept in
+// PreviousValues, so there is no Combiner
+type graphColoringProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(GRAPH_COLORING, newGraphColoringProgram)
+
+	// messages and partial results are sent as an interface{}
+	gob.Register(coloringPriority{})
+	gob.Register(coloringPartial{})
+}
+
+func newGraphColoringProgram(query Query) VertexProgram {
+	return &graphColoringProgram{query: query}
+}
+
+func (p *graphColoringProgram) Validate() error {
+	if p.query.IsTopKQuery() {
+		return errors.New("graph coloring does not rank the vertices")
+	}
+	if len(p.query.Nodes) > 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	return nil
+}
+
+func (p *graphColoringProgram) InitialValue(v *Vertex) interface{} {
+	return nil
+}
+
+// Compute records the colors of the neighbors colored by the last round,
+// then runs the phase of the round if the vertex is uncolored. Superstep 1
+// is the priority phase of the first round
+func (p *graphColoringProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	isHigherPriority := true
+	priority := p.priority(v)
+	for _, message := range v.Messages {
+		switch value := message.Value.(type) {
+		case uint64:
+			v.PreviousValues[message.SourceVertexId] = value
+		case coloringPriority:
+			if value.Priority > priority {
+				isHigherPriority = false
+			}
+		}
+	}
+	if v.CurrentValue != nil {
+		return nil
+	}
+
+	switch v.Phase() {
+	case "", COLORING_PRIORITY:
+		return p.sendToNeighbors(v, coloringPriority{Priority: priority})
+	case COLORING_COLOR:
+		if !isHigherPriority {
+			v.Aggregate(COLORING_UNCOLORED, 1)
+			return nil
+		}
+		color := p.smallestFreeColor(v)
+		v.CurrentValue = color
+		return p.sendToNeighbors(v, color)
+	}
+	return nil
+}
+
+// priority returns the priority of the vertex for the round. Hashing the id
+// with the round spreads the priorities, and breaks no ties within a round
+// since the hash is a bijection
+func (p *graphColoringProgram) priority(v *Vertex) uint64 {
+	round, _ := v.BroadcastValue(COLORING_ROUND)
+	return hashVertexId(v.Id ^ hashVertexId(uint64(round)))
+}
+
+// smallestFreeColor returns the smallest color none of the neighbors of the
+// vertex has
+func (p *graphColoringProgram) smallestFreeColor(v *Vertex) uint64 {
+	isUsed := make([]bool, len(v.PreviousValues)+1)
+	for _, value := range v.PreviousValues {
+		if color := value.(uint64); color < uint64(len(isUsed)) {
+			isUsed[color] = true
+		}
+	}
+	color := uint64(0)
+	for isUsed[color] {
+		color++
+	}
+	return color
+}
+
+func (p *graphColoringProgram) sendToNeighbors(
+	v *Vertex, value interface{},
+) []Message {
+	neighbors := v.UndirectedNeighbors()
+	result := make([]Message, 0, len(neighbors))
+	for _, neighborVertexId := range neighbors {
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          value,
+			},
+		)
+	}
+	return result
+}
+
+func (p *graphColoringProgram) Aggregators() map[string]string {
+	return map[string]string{COLORING_UNCOLORED: AGGREGATE_COUNT}
+}
+
+// MasterCompute alternates the phases of the rounds until a round leaves no
+// vertex uncolored. The colors of the last round are then delivered without
+// starting a phase, which ends the query
+func (p *graphColoringProgram) MasterCompute(master *MasterContext) {
+	switch master.Phase() {
+	case "", COLORING_PRIORITY:
+		master.SetPhase(COLORING_COLOR)
+	case COLORING_COLOR:
+		if master.Aggregated[COLORING_UNCOLORED] == 0 {
+			return
+		}
+		round, _ := master.BroadcastValue(COLORING_ROUND)
+		master.Broadcast(COLORING_ROUND, round+1)
+		master.SetPhase(COLORING_PRIORITY)
+	}
+}
+
+// PartialResult returns the number of colors of the worker's vertices, and
+// the color of the queried vertex if it is on this worker. A worker can be
+// done while messages to it are in flight, so some vertices may not be
+// colored yet
+func (p *graphColoringProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	partial := coloringPartial{}
+	for _, vertex := range vertices {
+		color, isColored := vertex.CurrentValue.(uint64)
+		if isColored && color+1 > partial.NumColors {
+			partial.NumColors = color + 1
+		}
+	}
+	if len(p.query.Nodes) == 1 {
+		if vertex, exists := vertices[p.query.Nodes[0]]; exists {
+			partial.QueriedColor, partial.HasQueried =
+				vertex.CurrentValue.(uint64)
+		}
+	}
+	return partial
+}
+
+// MergeResults returns the ColoringResult of the graph, with the color of the
+// queried vertex if there is one
+func (p *graphColoringProgram) MergeResults(
+	partials []interface{},
+) interface{} {
+	result := ColoringResult{}
+	for _, partial := range partials {
+		coloring := partial.(coloringPartial)
+		if coloring.NumColors > result.NumColors {
+			result.NumColors = coloring.NumColors
+		}
+		if coloring.HasQueried {
+			result.Color = coloring.QueriedColor
+		}
+	}
+	return result
+}
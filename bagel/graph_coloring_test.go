@@ -0,0 +1,73 @@
+package bagel
+
+import "testing"
+
+// the odd cycle 1 -> 2 -> 3 -> 4 -> 5 -> 1 needs three colors, and 6 has no
+// edges
+var testGraphColoringGraph = map[uint64][]uint64{
+	1: {2},
+	2: {3},
+	3: {4},
+	4: {5},
+	5: {1},
+	6: {},
+}
+
+func TestGraphColoring(t *testing.T) {
+	w, result := runTestQuery(
+		t, Query{QueryType: GRAPH_COLORING}, testGraphColoringGraph,
+	)
+
+	for id, neighbors := range testGraphColoringGraph {
+		for _, neighborId := range neighbors {
+			if w.Vertices[id].CurrentValue == w.Vertices[neighborId].CurrentValue {
+				t.Errorf(
+					"neighbors %v and %v have the same color %v", id,
+					neighborId, w.Vertices[id].CurrentValue,
+				)
+			}
+		}
+	}
+	if w.Vertices[6].CurrentValue != uint64(0) {
+		t.Errorf(
+			"expected color 0 for vertex 6 but got %v",
+			w.Vertices[6].CurrentValue,
+		)
+	}
+	if result != (ColoringResult{NumColors: 3}) {
+		t.Errorf("unexpected coloring result %v", result)
+	}
+}
+
+func TestGraphColoringOfVertex(t *testing.T) {
+	w, result := runTestQuery(
+		t, Query{QueryType: GRAPH_COLORING, Nodes: []uint64{3}},
+		testGraphColoringGraph,
+	)
+	expected := ColoringResult{
+		Color: w.Vertices[3].CurrentValue.(uint64), NumColors: 3,
+	}
+	if result != expected {
+		t.Errorf("expected coloring result %v but got %v", expected, result)
+	}
+}
+
+func TestGraphColoringCompleteGraph(t *testing.T) {
+	// a single vertex is colored per round, since every vertex is a
+	// neighbor of every other
+	graph := map[uint64][]uint64{1: {2, 3, 4, 5}, 2: {3, 4, 5}, 3: {4, 5}, 4: {5}, 5: {}}
+	_, result := runTestQuery(t, Query{QueryType: GRAPH_COLORING}, graph)
+	if result != (ColoringResult{NumColors: 5}) {
+		t.Errorf("unexpected coloring result %v", result)
+	}
+}
+
+func TestGraphColoringValidation(t *testing.T) {
+	if _, err := NewVertexProgram(
+		Query{
+			QueryType: GRAPH_COLORING, ResultMode: RESULT_TOP_K, TopK: 10,
+		},
+	); err == nil {
+		t.Errorf("graph coloring top k query is valid")
+	}
+}
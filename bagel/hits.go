@@ -0,0 +1,292 @@
+package bagel
+
+import (
+	"encoding/gob"
+	"errors"
+	"math"
+	coordgRPC "project/bagel/proto/coord"
+)
+
+// phases of a HITS query
+const (
+	HITS_AUTHORITY = "Authority"
+	HITS_HUB       = "Hub"
+	// the scores are normalized one last time before the query ends
+	HITS_NORMALIZE = "Normalize"
+)
+
+// HITS_HUB_NORM and HITS_AUTHORITY_NORM are the aggregators holding the sum
+// of the squares of the hub and authority scores, and the broadcast values
+// holding the L2 norms of the scores
+const (
+	HITS_HUB_NORM       = "HITSHubNorm"
+	HITS_AUTHORITY_NORM = "HITSAuthorityNorm"
+)
+
+// HITS_DELTA is the aggregator holding the L1 norm of the change in the
+// normalized scores at a superstep
+const HITS_DELTA = "HITSDelta"
+
+// query parameters of HITS queries
+const (
+	// HITS_TOLERANCE is the change in the scores under which the scores
+	// have converged
+	HITS_TOLERANCE = "Tolerance"
+	// HITS_MAX_SUPERSTEPS bounds the number of supersteps of a query whose
+	// scores do not converge
+	HITS_MAX_SUPERSTEPS = "MaxSuperSteps"
+)
+
+// default parameters of a HITS query
+const (
+	defaultHITSTolerance  = 1e-4
+	defaultHITSSuperSteps = 50
+)
+
+// HITSScores are the hub and authority scores of a vertex
+type HITSScores struct {
+	Hub       float64
+	Authority float64
+}
+
+// FillQueryResult sends the authority score as the Result, along with the
+// hub score
+func (s HITSScores) FillQueryResult(reply *coordgRPC.QueryResult) {
+	reply.Result = s.Authority
+	reply.HubScore = s.Hub
+}
+
+// HITSRanking holds the best hubs and authorities of a TopK query
+type HITSRanking struct {
+	Hubs        []RankedVertex
+	Authorities []RankedVertex
+}
+
+// Ranked returns the best authorities, the hubs being sent apart
+func (r HITSRanking) Ranked() []RankedVertex {
+	return r.Authorities
+}
+
+// FillQueryResult sends the best hubs, the authorities being sent as the
+// Ranking of the query
+func (r HITSRanking) FillQueryResult(reply *coordgRPC.QueryResult) {
+	reply.HubRanking = rankingReply(r.Hubs)
+}
+
+// hitsProgram computes the hub and authority scores of every vertex. The
+// authority of a vertex is the sum of the hub scores of its in-neighbors,
+// and the hub score of a vertex is the sum of the authorities of its
+// out-neighbors. Updates alternate between authorities and hubs, and every
+// update is divided by the L2 norm of the updated scores, which the
+// MasterComputer broadcasts after the barrier. Until then, the latest update
+// is kept in PreviousValues under the vertex's own id
+type hitsProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(HITS, newHITSProgram)
+
+	// scores and rankings are checkpointed and sent to the coord as an
+	// interface{}
+	gob.Register(HITSScores{})
+	gob.Register(HITSRanking{})
+}
+
+func newHITSProgram(query Query) VertexProgram {
+	return &hitsProgram{query: query}
+}
+
+func (p *hitsProgram) Validate() error {
+	if p.query.IsVertexValuesQuery() {
+		return errors.New("hits returns the scores of a vertex or a ranking")
+	}
+	if p.query.IsTargetQuery() && len(p.query.Nodes) != 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	if tolerance := p.tolerance(); math.IsNaN(tolerance) || tolerance <= 0 {
+		return errors.New("hits tolerance must be positive")
+	}
+	if !p.query.IsIntParam(
+		HITS_MAX_SUPERSTEPS, defaultHITSSuperSteps, 3, math.MaxInt32,
+	) {
+		return errors.New("hits needs a whole number of at least 3 supersteps")
+	}
+	return nil
+}
+
+func (p *hitsProgram) tolerance() float64 {
+	return p.query.Param(HITS_TOLERANCE, defaultHITSTolerance)
+}
+
+func (p *hitsProgram) maxSuperSteps() uint64 {
+	return uint64(p.query.Param(HITS_MAX_SUPERSTEPS, defaultHITSSuperSteps))
+}
+
+func (p *hitsProgram) InitialValue(v *Vertex) interface{} {
+	return HITSScores{Hub: 1, Authority: 1}
+}
+
+// Compute normalizes the scores with the latest norms, then updates the
+// scores of the phase. Superstep 1 updates the hub scores to 1
+func (p *hitsProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	updated := HITSScores{Hub: 1, Authority: 1}
+	if previous, exists := v.PreviousValues[v.Id]; exists {
+		updated = previous.(HITSScores)
+	}
+
+	scores := HITSScores{
+		Hub:       updated.Hub / p.norm(v, HITS_HUB_NORM),
+		Authority: updated.Authority / p.norm(v, HITS_AUTHORITY_NORM),
+	}
+	current := v.CurrentValue.(HITSScores)
+	v.Aggregate(
+		HITS_DELTA,
+		math.Abs(scores.Hub-current.Hub)+
+			math.Abs(scores.Authority-current.Authority),
+	)
+	v.CurrentValue = scores
+
+	var result []Message
+	switch v.Phase() {
+	case "":
+		v.Aggregate(HITS_HUB_NORM, 1)
+		result = p.sendScore(v, v.Neighbors, 1)
+	case HITS_AUTHORITY:
+		// the hub scores of the in-neighbors are divided by their norm
+		updated.Authority = p.sumMessages(v) / p.norm(v, HITS_HUB_NORM)
+		v.Aggregate(
+			HITS_AUTHORITY_NORM, updated.Authority*updated.Authority,
+		)
+		result = p.sendScore(v, v.InNeighbors, updated.Authority)
+	case HITS_HUB:
+		updated.Hub = p.sumMessages(v) / p.norm(v, HITS_AUTHORITY_NORM)
+		v.Aggregate(HITS_HUB_NORM, updated.Hub*updated.Hub)
+		result = p.sendScore(v, v.Neighbors, updated.Hub)
+	}
+	v.PreviousValues[v.Id] = updated
+	return result
+}
+
+// norm returns the broadcast norm of the scores, or 1 if the scores were
+// never updated
+func (p *hitsProgram) norm(v *Vertex, name string) float64 {
+	if norm, exists := v.BroadcastValue(name); exists {
+		return norm
+	}
+	return 1
+}
+
+func (p *hitsProgram) sumMessages(v *Vertex) float64 {
+	sum := 0.0
+	for _, message := range v.Messages {
+		sum += message.Value.(float64)
+	}
+	return sum
+}
+
+// sendScore sends the updated score to the neighbors
+func (p *hitsProgram) sendScore(
+	v *Vertex, neighbors []uint64, score float64,
+) []Message {
+	result := make([]Message, 0, len(neighbors))
+	for _, neighborVertexId := range neighbors {
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          score,
+			},
+		)
+	}
+	return result
+}
+
+func (p *hitsProgram) Aggregators() map[string]string {
+	return map[string]string{
+		HITS_HUB_NORM:       AGGREGATE_SUM,
+		HITS_AUTHORITY_NORM: AGGREGATE_SUM,
+		HITS_DELTA:          AGGREGATE_SUM,
+	}
+}
+
+// MasterCompute broadcasts the norms of the updated scores and alternates
+// between the authority and hub phases, until the scores converge or the
+// query runs out of supersteps. The last phase normalizes the scores and
+// ends the query
+func (p *hitsProgram) MasterCompute(master *MasterContext) {
+	if master.Phase() == HITS_NORMALIZE {
+		return
+	}
+
+	for _, name := range []string{HITS_HUB_NORM, HITS_AUTHORITY_NORM} {
+		// scores that are all zero are left as they are
+		if sumOfSquares := master.Aggregated[name]; sumOfSquares > 0 {
+			master.Broadcast(name, math.Sqrt(sumOfSquares))
+		}
+	}
+
+	// the first update of each score changes it from its initial value
+	isConverged := master.SuperStepNum > 3 &&
+		master.Aggregated[HITS_DELTA] < p.tolerance()
+	if isConverged || master.SuperStepNum+1 >= p.maxSuperSteps() {
+		master.SetPhase(HITS_NORMALIZE)
+	} else if master.Phase() == HITS_AUTHORITY {
+		master.SetPhase(HITS_HUB)
+	} else {
+		master.SetPhase(HITS_AUTHORITY)
+	}
+}
+
+// PartialResult returns the scores of the queried vertex if it is on this
+// worker, or the best hubs and authorities of the worker for TopK queries
+func (p *hitsProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	if !p.query.IsTopKQuery() {
+		if vertex, exists := vertices[p.query.Nodes[0]]; exists {
+			return vertex.CurrentValue
+		}
+		return nil
+	}
+
+	hubs := make([]RankedVertex, 0, len(vertices))
+	authorities := make([]RankedVertex, 0, len(vertices))
+	for _, vertex := range vertices {
+		scores := vertex.CurrentValue.(HITSScores)
+		hubs = append(
+			hubs, RankedVertex{VertexId: vertex.Id, Score: scores.Hub},
+		)
+		authorities = append(
+			authorities,
+			RankedVertex{VertexId: vertex.Id, Score: scores.Authority},
+		)
+	}
+	k := int(p.query.TopK)
+	return HITSRanking{Hubs: topK(hubs, k), Authorities: topK(authorities, k)}
+}
+
+// MergeResults returns the HITSScores of the queried vertex, or the
+// HITSRanking of the graph for TopK queries
+func (p *hitsProgram) MergeResults(partials []interface{}) interface{} {
+	if !p.query.IsTopKQuery() {
+		if len(partials) == 0 {
+			return nil
+		}
+		return partials[0]
+	}
+
+	hubs := make([][]RankedVertex, 0, len(partials))
+	authorities := make([][]RankedVertex, 0, len(partials))
+	for _, partial := range partials {
+		ranking := partial.(HITSRanking)
+		hubs = append(hubs, ranking.Hubs)
+		authorities = append(authorities, ranking.Authorities)
+	}
+	k := int(p.query.TopK)
+	return HITSRanking{
+		Hubs: mergeTopK(hubs, k), Authorities: mergeTopK(authorities, k),
+	}
+}
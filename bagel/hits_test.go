@@ -0,0 +1,76 @@
+package bagel
+
+import (
+	"math"
+	"testing"
+)
+
+// 1 and 2 are hubs pointing to the authorities 3 and 4
+var testHITSGraph = map[uint64][]uint64{1: {3}, 2: {3, 4}, 3: {}, 4: {1}}
+
+func TestHITSScores(t *testing.T) {
+	w, result := runTestQuery(
+		t, Query{QueryType: HITS, Nodes: []uint64{2}}, testHITSGraph,
+	)
+
+	expected := map[uint64]HITSScores{
+		1: {Hub: 0.525731, Authority: 0},
+		2: {Hub: 0.850651, Authority: 0},
+		3: {Hub: 0, Authority: 0.850651},
+		4: {Hub: 0, Authority: 0.525731},
+	}
+	for id, scores := range expected {
+		actual := w.Vertices[id].CurrentValue.(HITSScores)
+		if math.Abs(actual.Hub-scores.Hub) > 1e-3 ||
+			math.Abs(actual.Authority-scores.Authority) > 1e-3 {
+			t.Errorf(
+				"vertex %v: expected scores %v but got %v", id, scores, actual,
+			)
+		}
+	}
+	if result != w.Vertices[2].CurrentValue {
+		t.Errorf("expected the scores of vertex 2 but got %v", result)
+	}
+}
+
+func TestHITSTopK(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{QueryType: HITS, ResultMode: RESULT_TOP_K, TopK: 1},
+		testHITSGraph,
+	)
+
+	ranking := result.(HITSRanking)
+	if len(ranking.Hubs) != 1 || ranking.Hubs[0].VertexId != 2 {
+		t.Errorf("expected vertex 2 as the best hub but got %v", ranking.Hubs)
+	}
+	if len(ranking.Authorities) != 1 || ranking.Authorities[0].VertexId != 3 {
+		t.Errorf(
+			"expected vertex 3 as the best authority but got %v",
+			ranking.Authorities,
+		)
+	}
+}
+
+func TestHITSValidation(t *testing.T) {
+	if _, err := NewVertexProgram(
+		Query{QueryType: HITS, ResultMode: RESULT_VERTEX_VALUES},
+	); err == nil {
+		t.Errorf("hits vertex values query is valid")
+	}
+	if _, err := NewVertexProgram(Query{QueryType: HITS}); err == nil {
+		t.Errorf("hits query without a vertex is valid")
+	}
+	for _, params := range []map[string]float64{
+		{HITS_MAX_SUPERSTEPS: 2},
+		{HITS_MAX_SUPERSTEPS: 3.5},
+		{HITS_MAX_SUPERSTEPS: math.NaN()},
+		{HITS_TOLERANCE: 0},
+		{HITS_TOLERANCE: math.NaN()},
+	} {
+		if _, err := NewVertexProgram(
+			Query{QueryType: HITS, Nodes: []uint64{1}, Params: params},
+		); err == nil {
+			t.Errorf("hits query with params %v is valid", params)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package bagel
+
+import (
+	"encoding/gob"
+	"errors"
+	"math"
+	coordgRPC "project/bagel/proto/coord"
+)
+
+// query parameters of HyperANF queries
+const (
+	// HYPER_ANF_PRECISION is the base 2 logarithm of the number of registers
+	// of the sketches, which trades memory and message size for accuracy
+	HYPER_ANF_PRECISION = "Precision"
+	// HYPER_ANF_MAX_HOPS bounds the distances the neighborhood function is
+	// estimated for
+	HYPER_ANF_MAX_HOPS = "MaxHops"
+)
+
+// default parameters of a HyperANF query
+const (
+	defaultHyperANFPrecision = 7
+	defaultHyperANFHops      = math.MaxInt32
+)
+
+// effectiveDiameterFraction is the fraction of the pairs of vertices with a
+// path between them that are within the effective diameter
+const effectiveDiameterFraction = 0.9
+
+// NeighborhoodFunction is the result of a HyperANF query
+type NeighborhoodFunction struct {
+	// estimated number of pairs of vertices (x, y) with a path of at most
+	// t hops from x to y, including the pairs (x, x), for every t
+	NumPairs []float64
+	// interpolated number of hops within which 90% of the pairs with a path
+	// are
+	EffectiveDiameter float64
+	// average distance between the connected pairs of distinct vertices
+	AverageDistance float64
+}
+
+// FillQueryResult sends the effective diameter as the Result, along with the
+// neighborhood function and the average distance
+func (f NeighborhoodFunction) FillQueryResult(reply *coordgRPC.QueryResult) {
+	reply.Result = f.EffectiveDiameter
+	reply.NeighborhoodFunction = f.NumPairs
+	reply.AverageDistance = f.AverageDistance
+}
+
+// HyperANFCounter is the value of a vertex in a HyperANF query: the sketch
+// of the vertices that reach it, and the estimated number of those vertices
+// within every number of hops
+type HyperANFCounter struct {
+	Sketch    HyperLogLog
+	Estimates []float64
+}
+
+// hyperANFProgram estimates the neighborhood function of the graph with
+// HyperANF. At superstep t + 1, the sketch of every vertex holds the
+// vertices with a path of at most t hops to it, from the union of the
+// sketches of its in-neighbors at superstep t. The neighborhood function at t
+// is the sum of the estimates of the sketches. There is no Combiner since
+// the sketches of a vertex's in-neighbors are merged by Compute anyway
+type hyperANFProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(HYPER_ANF, newHyperANFProgram)
+
+	// vertex values are checkpointed and partial results are sent to the
+	// coord as an interface{}
+	gob.Register(HyperANFCounter{})
+	gob.Register([]float64{})
+}
+
+func newHyperANFProgram(query Query) VertexProgram {
+	return &hyperANFProgram{query: query}
+}
+
+func (p *hyperANFProgram) Validate() error {
+	if !p.query.IsTargetQuery() || len(p.query.Nodes) > 0 {
+		return errors.New("hyperanf estimates the neighborhood of the graph")
+	}
+	if !p.query.IsIntParam(
+		HYPER_ANF_PRECISION, defaultHyperANFPrecision, 4, 16,
+	) {
+		return errors.New("hyperanf precision must be between 4 and 16")
+	}
+	if !p.query.IsIntParam(
+		HYPER_ANF_MAX_HOPS, defaultHyperANFHops, 1, math.MaxInt32,
+	) {
+		return errors.New("hyperanf needs a whole number of hops")
+	}
+	return nil
+}
+
+func (p *hyperANFProgram) precision() uint8 {
+	return uint8(p.query.Param(HYPER_ANF_PRECISION, defaultHyperANFPrecision))
+}
+
+func (p *hyperANFProgram) maxHops() int {
+	return int(p.query.Param(HYPER_ANF_MAX_HOPS, defaultHyperANFHops))
+}
+
+// InitialValue is the sketch of the vertex itself, which is the only vertex
+// within 0 hops
+func (p *hyperANFProgram) InitialValue(v *Vertex) interface{} {
+	sketch := NewHyperLogLog(p.precision(), v.Id)
+	return HyperANFCounter{
+		Sketch: sketch, Estimates: []float64{sketch.Estimate()},
+	}
+}
+
+func (p *hyperANFProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	counter := v.CurrentValue.(HyperANFCounter)
+	if v.SuperStepNum() <= 1 {
+		return p.sendSketch(v, counter.Sketch)
+	}
+
+	sketch := counter.Sketch
+	isChanged := false
+	for _, message := range v.Messages {
+		var isUnionChanged bool
+		sketch, isUnionChanged = sketch.Union(message.Value.(HyperLogLog))
+		isChanged = isChanged || isUnionChanged
+	}
+	if !isChanged {
+		return nil
+	}
+
+	// the estimates of the hops the sketch did not change at are the same
+	// as the last one
+	hops := int(v.SuperStepNum() - 1)
+	estimates := append([]float64(nil), counter.Estimates...)
+	for len(estimates) < hops {
+		estimates = append(estimates, estimates[len(estimates)-1])
+	}
+	v.CurrentValue = HyperANFCounter{
+		Sketch: sketch, Estimates: append(estimates, sketch.Estimate()),
+	}
+
+	if hops >= p.maxHops() {
+		return nil
+	}
+	return p.sendSketch(v, sketch)
+}
+
+// sendSketch sends the sketch to the out-neighbors, skipping self-loops
+func (p *hyperANFProgram) sendSketch(
+	v *Vertex, sketch HyperLogLog,
+) []Message {
+	result := make([]Message, 0, len(v.Neighbors))
+	for _, neighborVertexId := range v.Neighbors {
+		if neighborVertexId == v.Id {
+			continue
+		}
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          sketch,
+			},
+		)
+	}
+	return result
+}
+
+// PartialResult returns the neighborhood function of the worker's vertices,
+// up to the last hop any of their sketches changed at
+func (p *hyperANFProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	numHops := 0
+	for _, vertex := range vertices {
+		estimates := vertex.CurrentValue.(HyperANFCounter).Estimates
+		if len(estimates) > numHops {
+			numHops = len(estimates)
+		}
+	}
+
+	numPairs := make([]float64, numHops)
+	for _, vertex := range vertices {
+		addEstimates(numPairs, vertex.CurrentValue.(HyperANFCounter).Estimates)
+	}
+	return numPairs
+}
+
+// addEstimates adds the estimates to the sum of every hop, extending them
+// with their last value
+func addEstimates(sums []float64, estimates []float64) {
+	for hops := range sums {
+		if hops < len(estimates) {
+			sums[hops] += estimates[hops]
+		} else {
+			sums[hops] += estimates[len(estimates)-1]
+		}
+	}
+}
+
+// MergeResults returns the NeighborhoodFunction of the graph
+func (p *hyperANFProgram) MergeResults(partials []interface{}) interface{} {
+	numHops := 0
+	for _, partial := range partials {
+		if len(partial.([]float64)) > numHops {
+			numHops = len(partial.([]float64))
+		}
+	}
+
+	result := NeighborhoodFunction{NumPairs: make([]float64, numHops)}
+	for _, partial := range partials {
+		if len(partial.([]float64)) > 0 {
+			addEstimates(result.NumPairs, partial.([]float64))
+		}
+	}
+	if numHops < 2 {
+		return result
+	}
+
+	// pairs of distinct vertices at each distance
+	numConnected := result.NumPairs[numHops-1] - result.NumPairs[0]
+	if numConnected <= 0 {
+		return result
+	}
+	totalDistance := 0.0
+	for hops := 1; hops < numHops; hops++ {
+		numAtDistance := result.NumPairs[hops] - result.NumPairs[hops-1]
+		totalDistance += float64(hops) * numAtDistance
+	}
+	result.AverageDistance = totalDistance / numConnected
+
+	// the effective diameter is interpolated between the last distance under
+	// the threshold and the next one
+	threshold := effectiveDiameterFraction * result.NumPairs[numHops-1]
+	if result.NumPairs[0] >= threshold {
+		return result
+	}
+	for hops := 1; hops < numHops; hops++ {
+		if result.NumPairs[hops] < threshold {
+			continue
+		}
+		previous := result.NumPairs[hops-1]
+		result.EffectiveDiameter = float64(hops-1) +
+			(threshold-previous)/(result.NumPairs[hops]-previous)
+		break
+	}
+	return result
+}
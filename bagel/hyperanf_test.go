@@ -0,0 +1,81 @@
+package bagel
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHyperANFNeighborhoodFunction(t *testing.T) {
+	graph := map[uint64][]uint64{1: {2}, 2: {3}, 3: {4}, 4: {}}
+	_, result := runTestQuery(
+		t, Query{
+			QueryType: HYPER_ANF,
+			Params:    map[string]float64{HYPER_ANF_PRECISION: 10},
+		}, graph,
+	)
+
+	// 3 pairs are 1 hop apart, 2 pairs 2 hops and 1 pair 3 hops
+	neighborhood := result.(NeighborhoodFunction)
+	expected := []float64{4, 7, 9, 10}
+	if len(neighborhood.NumPairs) != len(expected) {
+		t.Fatalf(
+			"expected neighborhood function %v but got %v", expected,
+			neighborhood.NumPairs,
+		)
+	}
+	for hops, numPairs := range expected {
+		if math.Abs(neighborhood.NumPairs[hops]-numPairs) > 0.1 {
+			t.Errorf(
+				"expected neighborhood function %v but got %v", expected,
+				neighborhood.NumPairs,
+			)
+		}
+	}
+	if math.Abs(neighborhood.EffectiveDiameter-2) > 0.1 {
+		t.Errorf(
+			"expected an effective diameter of 2 but got %v",
+			neighborhood.EffectiveDiameter,
+		)
+	}
+	if math.Abs(neighborhood.AverageDistance-10.0/6) > 0.1 {
+		t.Errorf(
+			"expected an average distance of 1.67 but got %v",
+			neighborhood.AverageDistance,
+		)
+	}
+}
+
+func TestHyperANFMaxHops(t *testing.T) {
+	graph := map[uint64][]uint64{1: {2}, 2: {3}, 3: {4}, 4: {}}
+	_, result := runTestQuery(
+		t, Query{
+			QueryType: HYPER_ANF,
+			Params:    map[string]float64{HYPER_ANF_MAX_HOPS: 1},
+		}, graph,
+	)
+	if numPairs := result.(NeighborhoodFunction).NumPairs; len(numPairs) != 2 {
+		t.Errorf("expected the neighborhood function of 1 hop but got %v", numPairs)
+	}
+}
+
+func TestHyperANFValidation(t *testing.T) {
+	if _, err := NewVertexProgram(
+		Query{QueryType: HYPER_ANF, Nodes: []uint64{1}},
+	); err == nil {
+		t.Errorf("hyperanf query for a vertex is valid")
+	}
+	for _, params := range []map[string]float64{
+		{HYPER_ANF_PRECISION: 20},
+		{HYPER_ANF_PRECISION: 7.5},
+		{HYPER_ANF_PRECISION: math.NaN()},
+		{HYPER_ANF_MAX_HOPS: 0},
+		{HYPER_ANF_MAX_HOPS: math.NaN()},
+		{HYPER_ANF_MAX_HOPS: math.Inf(1)},
+	} {
+		if _, err := NewVertexProgram(
+			Query{QueryType: HYPER_ANF, Params: params},
+		); err == nil {
+			t.Errorf("hyperanf query with params %v is valid", params)
+		}
+	}
+}
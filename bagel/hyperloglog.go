@@ -0,0 +1,111 @@
+package bagel
+
+import (
+	"encoding/gob"
+	"math"
+	"math/bits"
+)
+
+// HyperLogLog is a sketch estimating the number of distinct vertex ids added
+// to it, with a relative error of about 1.04 / sqrt(len(Registers)). The
+// registers are exported for sketches to be gob encoded in messages and
+// checkpoints. Sketches are never modified once they are shared, Union
+// copies the registers it changes
+type HyperLogLog struct {
+	Registers []uint8
+}
+
+func init() {
+	// sketches are sent and checkpointed as an interface{}
+	gob.Register(HyperLogLog{})
+}
+
+// NewHyperLogLog returns a sketch of 2^precision registers holding the ids
+func NewHyperLogLog(precision uint8, ids ...uint64) HyperLogLog {
+	h := HyperLogLog{Registers: make([]uint8, 1<<precision)}
+	for _, id := range ids {
+		h.add(id)
+	}
+	return h
+}
+
+func (h HyperLogLog) add(id uint64) {
+	precision := bits.TrailingZeros(uint(len(h.Registers)))
+	hash := hashVertexId(id)
+	register := hash >> (64 - precision)
+
+	// the rank is the position of the first 1 bit after the register bits
+	rank := uint8(bits.LeadingZeros64(hash<<precision) + 1)
+	if maxRank := uint8(64 - precision + 1); rank > maxRank {
+		rank = maxRank
+	}
+	if rank > h.Registers[register] {
+		h.Registers[register] = rank
+	}
+}
+
+// hashVertexId spreads vertex ids over 64 bits with the splitmix64
+// finalizer, since consecutive ids would otherwise share their high bits
+func hashVertexId(id uint64) uint64 {
+	id ^= id >> 30
+	id *= 0xbf58476d1ce4e5b9
+	id ^= id >> 27
+	id *= 0x94d049bb133111eb
+	id ^= id >> 31
+	return id
+}
+
+// Union returns the sketch of the ids of both sketches, which must have the
+// same precision, and whether it holds more than h
+func (h HyperLogLog) Union(other HyperLogLog) (HyperLogLog, bool) {
+	union := h
+	isChanged := false
+	for idx, rank := range other.Registers {
+		if rank <= union.Registers[idx] {
+			continue
+		}
+		if !isChanged {
+			union = HyperLogLog{
+				Registers: append([]uint8(nil), h.Registers...),
+			}
+			isChanged = true
+		}
+		union.Registers[idx] = rank
+	}
+	return union, isChanged
+}
+
+// Estimate returns the estimated number of distinct ids in the sketch, using
+// linear counting for small numbers of ids
+func (h HyperLogLog) Estimate() float64 {
+	numRegisters := float64(len(h.Registers))
+	sum := 0.0
+	numZeros := 0
+	for _, rank := range h.Registers {
+		sum += math.Ldexp(1, -int(rank))
+		if rank == 0 {
+			numZeros++
+		}
+	}
+
+	estimate := hyperLogLogAlpha(len(h.Registers)) * numRegisters *
+		numRegisters / sum
+	if estimate <= 2.5*numRegisters && numZeros > 0 {
+		return numRegisters * math.Log(numRegisters/float64(numZeros))
+	}
+	return estimate
+}
+
+// hyperLogLogAlpha corrects the bias of the estimate for the number of
+// registers
+func hyperLogLogAlpha(numRegisters int) float64 {
+	switch numRegisters {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(numRegisters))
+}
@@ -0,0 +1,77 @@
+package bagel
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestHyperLogLogEstimate(t *testing.T) {
+	ids := make([]uint64, 0, 10000)
+	for id := uint64(1); id <= 10000; id++ {
+		ids = append(ids, id)
+	}
+	sketch := NewHyperLogLog(10, ids...)
+	if estimate := sketch.Estimate(); math.Abs(estimate-10000) > 1000 {
+		t.Errorf("expected an estimate close to 10000 but got %v", estimate)
+	}
+
+	// linear counting is close to exact for a few ids
+	small := NewHyperLogLog(10, 1, 2, 3, 4, 5)
+	if estimate := small.Estimate(); math.Abs(estimate-5) > 0.1 {
+		t.Errorf("expected an estimate close to 5 but got %v", estimate)
+	}
+}
+
+func TestHyperLogLogUnion(t *testing.T) {
+	a := NewHyperLogLog(6, 1, 2)
+	b := NewHyperLogLog(6, 2, 3)
+	registers := append([]uint8(nil), a.Registers...)
+
+	union, isChanged := a.Union(b)
+	if !isChanged || !reflect.DeepEqual(union, NewHyperLogLog(6, 1, 2, 3)) {
+		t.Errorf("unexpected union %v", union)
+	}
+	if !reflect.DeepEqual(a.Registers, registers) {
+		t.Errorf("union modified the sketch")
+	}
+	if _, isChanged := union.Union(a); isChanged {
+		t.Errorf("union with a subset changed the sketch")
+	}
+}
+
+func TestHyperLogLogGobEncoding(t *testing.T) {
+	sketch := NewHyperLogLog(6, 1, 2, 3)
+	checkpoint := VertexCheckpoint{
+		Id:           1,
+		CurrentValue: HyperANFCounter{Sketch: sketch, Estimates: []float64{1}},
+	}
+	messages := []Message{{SourceVertexId: 1, DestVertexId: 2, Value: sketch}}
+
+	var buf bytes.Buffer
+	encoder := gob.NewEncoder(&buf)
+	if err := encoder.Encode(checkpoint); err != nil {
+		t.Fatalf("error encoding checkpoint: %v", err)
+	}
+	if err := encoder.Encode(messages); err != nil {
+		t.Fatalf("error encoding messages: %v", err)
+	}
+
+	var decodedCheckpoint VertexCheckpoint
+	var decodedMessages []Message
+	decoder := gob.NewDecoder(&buf)
+	if err := decoder.Decode(&decodedCheckpoint); err != nil {
+		t.Fatalf("error decoding checkpoint: %v", err)
+	}
+	if err := decoder.Decode(&decodedMessages); err != nil {
+		t.Fatalf("error decoding messages: %v", err)
+	}
+	if !reflect.DeepEqual(decodedCheckpoint, checkpoint) {
+		t.Errorf("expected checkpoint %v but got %v", checkpoint, decodedCheckpoint)
+	}
+	if !reflect.DeepEqual(decodedMessages, messages) {
+		t.Errorf("expected messages %v but got %v", messages, decodedMessages)
+	}
+}
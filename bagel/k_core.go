@@ -0,0 +1,143 @@
+package bagel
+
+import (
+	"errors"
+	coordgRPC "project/bagel/proto/coord"
+)
+
+// CoreResult is the result of a k-core query: the coreness of the queried
+// vertex, and the largest k with a non-empty k-core with its number of
+// vertices
+type CoreResult struct {
+	Coreness    uint64
+	MaxCoreness uint64
+	MaxCoreSize uint64
+}
+
+// FillQueryResult sends the coreness as the Result, along with the largest
+// coreness of the graph and the size of its core
+func (r CoreResult) FillQueryResult(reply *coordgRPC.QueryResult) {
+	reply.Result = float64(r.Coreness)
+	reply.MaxCoreness = r.MaxCoreness
+	reply.MaxCoreSize = r.MaxCoreSize
+}
+
+// kCoreProgram computes the coreness of every vertex, the largest k such
+// that the vertex is in the k-core, ignoring the direction of the edges.
+// The coreness estimate of a vertex starts at its degree and drops to the
+// largest k such that k neighbors have an estimate of at least k, until no
+// estimate changes. There is no Combiner since Compute keeps the latest
+// estimate of every neighbor in PreviousValues
+type kCoreProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(K_CORE, newKCoreProgram)
+}
+
+func newKCoreProgram(query Query) VertexProgram {
+	return &kCoreProgram{query: query}
+}
+
+func (p *kCoreProgram) Validate() error {
+	if len(p.query.Nodes) > 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	return nil
+}
+
+func (p *kCoreProgram) InitialValue(v *Vertex) interface{} {
+	return uint64(0)
+}
+
+func (p *kCoreProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	if v.SuperStepNum() <= 1 {
+		v.CurrentValue = uint64(len(v.UndirectedNeighbors()))
+		return p.sendCoreness(v)
+	}
+
+	for _, message := range v.Messages {
+		v.PreviousValues[message.SourceVertexId] = message.Value
+	}
+
+	coreness := p.hIndex(v)
+	if coreness >= v.CurrentValue.(uint64) {
+		return nil
+	}
+	v.CurrentValue = coreness
+	return p.sendCoreness(v)
+}
+
+// hIndex returns the largest k, up to the current estimate of the vertex,
+// such that k neighbors have an estimate of at least k
+func (p *kCoreProgram) hIndex(v *Vertex) uint64 {
+	estimate := v.CurrentValue.(uint64)
+	counts := make([]uint64, estimate+1)
+	for _, value := range v.PreviousValues {
+		neighborEstimate := value.(uint64)
+		if neighborEstimate > estimate {
+			neighborEstimate = estimate
+		}
+		counts[neighborEstimate]++
+	}
+
+	numNeighbors := uint64(0)
+	for k := estimate; k > 0; k-- {
+		numNeighbors += counts[k]
+		if numNeighbors >= k {
+			return k
+		}
+	}
+	return 0
+}
+
+// sendCoreness sends the coreness estimate of the vertex to its neighbors
+func (p *kCoreProgram) sendCoreness(v *Vertex) []Message {
+	neighbors := v.UndirectedNeighbors()
+	result := make([]Message, 0, len(neighbors))
+	for _, neighborVertexId := range neighbors {
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          v.CurrentValue,
+			},
+		)
+	}
+	return result
+}
+
+// PartialResult counts the worker's vertices of each coreness, the way
+// components are counted by their id
+func (p *kCoreProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	return partialComponentSizes(p.query, vertices)
+}
+
+// MergeResults returns the CoreResult of the graph, with the coreness of the
+// queried vertex if there is one
+func (p *kCoreProgram) MergeResults(partials []interface{}) interface{} {
+	result := CoreResult{}
+	numVertices := make(map[uint64]uint64)
+	for _, partial := range partials {
+		corenessPartial := partial.(componentSizesPartial)
+		for coreness, size := range corenessPartial.Sizes {
+			numVertices[coreness] += size
+			if coreness > result.MaxCoreness {
+				result.MaxCoreness = coreness
+			}
+		}
+		if corenessPartial.HasQueried {
+			result.Coreness = corenessPartial.QueriedComponent
+		}
+	}
+
+	// the k-core holds the vertices of coreness k or more, and no vertex
+	// has a coreness above the largest one
+	result.MaxCoreSize = numVertices[result.MaxCoreness]
+	return result
+}
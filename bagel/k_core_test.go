@@ -0,0 +1,42 @@
+package bagel
+
+import "testing"
+
+// the clique {1, 2, 3, 4} is the 3-core, 5 joins it in the 2-core and 6 in
+// the 1-core, and 7 has no edges
+var testKCoreGraph = map[uint64][]uint64{
+	1: {2, 3, 4},
+	2: {3, 4},
+	3: {4},
+	4: {},
+	5: {1, 2},
+	6: {5},
+	7: {},
+}
+
+func TestKCoreCoreness(t *testing.T) {
+	w, result := runTestQuery(t, Query{QueryType: K_CORE}, testKCoreGraph)
+
+	expected := map[uint64]uint64{1: 3, 2: 3, 3: 3, 4: 3, 5: 2, 6: 1, 7: 0}
+	for id, coreness := range expected {
+		if w.Vertices[id].CurrentValue != coreness {
+			t.Errorf(
+				"vertex %v: expected coreness %v but got %v", id, coreness,
+				w.Vertices[id].CurrentValue,
+			)
+		}
+	}
+	if result != (CoreResult{MaxCoreness: 3, MaxCoreSize: 4}) {
+		t.Errorf("unexpected k-core result %v", result)
+	}
+}
+
+func TestKCoreOfVertex(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{QueryType: K_CORE, Nodes: []uint64{5}}, testKCoreGraph,
+	)
+	expected := CoreResult{Coreness: 2, MaxCoreness: 3, MaxCoreSize: 4}
+	if result != expected {
+		t.Errorf("expected k-core result %v but got %v", expected, result)
+	}
+}
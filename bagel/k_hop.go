@@ -0,0 +1,109 @@
+package bagel
+
+import (
+	"errors"
+	"math"
+)
+
+// K_HOP_MAX_DEPTH is the query parameter bounding the number of hops from
+// the sources of a k-hop neighborhood query
+const K_HOP_MAX_DEPTH = "MaxDepth"
+
+// kHopProgram finds the vertices within K_HOP_MAX_DEPTH hops of any of the
+// sources of the query with a breadth-first search along out-edges. The
+// value of a reached vertex is its distance to the closest source, and
+// vertices that are not reached have no value, so VertexValues queries only
+// return the reachable vertices. Vertices at the maximum depth do not
+// forward the search, which ends the query after MaxDepth + 1 supersteps
+// instead of running to convergence
+type kHopProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(K_HOP_NEIGHBORHOOD, newKHopProgram)
+}
+
+func newKHopProgram(query Query) VertexProgram {
+	return &kHopProgram{query: query}
+}
+
+func (p *kHopProgram) Validate() error {
+	if len(p.query.Nodes) == 0 {
+		return errors.New("k-hop neighborhood needs at least one source vertex")
+	}
+	if !p.query.IsIntParam(K_HOP_MAX_DEPTH, -1, 0, math.MaxInt32) {
+		return errors.New("k-hop MaxDepth must be a non-negative whole number")
+	}
+	return nil
+}
+
+func (p *kHopProgram) maxDepth() int {
+	return int(p.query.Param(K_HOP_MAX_DEPTH, 0))
+}
+
+func (p *kHopProgram) InitialValue(v *Vertex) interface{} {
+	return nil
+}
+
+func (p *kHopProgram) InitialMessages(v *Vertex) []Message {
+	for _, sourceVertexId := range p.query.Nodes {
+		if v.Id == sourceVertexId {
+			return []Message{{INITIALIZATION_VERTEX, v.Id, 0}}
+		}
+	}
+	return nil
+}
+
+func (p *kHopProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	// the search reaches a vertex at most once, at its shortest distance
+	if v.CurrentValue != nil || len(v.Messages) == 0 {
+		return nil
+	}
+
+	depth := v.Messages[0].Value.(int)
+	v.CurrentValue = depth
+	if depth >= p.maxDepth() {
+		return nil
+	}
+
+	result := make([]Message, 0, len(v.Neighbors))
+	for _, neighborVertexId := range v.Neighbors {
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          depth + 1,
+			},
+		)
+	}
+	return result
+}
+
+// Combine keeps a single message, since all the messages a vertex receives
+// at a superstep hold the same distance
+func (p *kHopProgram) Combine(a Message, b Message) Message {
+	return minMessage(a, b)
+}
+
+// PartialResult counts the worker's reachable vertices
+func (p *kHopProgram) PartialResult(vertices map[uint64]*Vertex) interface{} {
+	numReachable := 0
+	for _, vertex := range vertices {
+		if vertex.CurrentValue != nil {
+			numReachable++
+		}
+	}
+	return numReachable
+}
+
+// MergeResults returns the number of reachable vertices, sources included
+func (p *kHopProgram) MergeResults(partials []interface{}) interface{} {
+	numReachable := 0
+	for _, partial := range partials {
+		numReachable += partial.(int)
+	}
+	return numReachable
+}
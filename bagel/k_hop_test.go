@@ -0,0 +1,62 @@
+package bagel
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestKHopNeighborhood(t *testing.T) {
+	graph := map[uint64][]uint64{
+		1: {2},
+		2: {3},
+		3: {4},
+		4: {},
+		5: {3},
+		6: {1},
+	}
+	w, result := runTestQuery(
+		t, Query{
+			QueryType: K_HOP_NEIGHBORHOOD, Nodes: []uint64{1, 5},
+			ResultMode: RESULT_VERTEX_VALUES,
+			Params:     map[string]float64{K_HOP_MAX_DEPTH: 1},
+		}, graph,
+	)
+
+	// 3 is one hop from 5, and 4 is out of reach
+	var values VertexValuesResult
+	w.GetVertexValues(VertexValuesRequest{}, &values)
+	expected := map[uint64]float64{1: 0, 2: 1, 3: 1, 5: 0}
+	if !reflect.DeepEqual(values.Values, expected) {
+		t.Errorf("expected distances %v but got %v", expected, values.Values)
+	}
+	if result != len(expected) {
+		t.Errorf("expected %v reachable vertices but got %v", len(expected), result)
+	}
+}
+
+func TestKHopNeighborhoodValidation(t *testing.T) {
+	if _, err := NewVertexProgram(
+		Query{
+			QueryType: K_HOP_NEIGHBORHOOD,
+			Params:    map[string]float64{K_HOP_MAX_DEPTH: 1},
+		},
+	); err == nil {
+		t.Errorf("k-hop query without sources is valid")
+	}
+	if _, err := NewVertexProgram(
+		Query{QueryType: K_HOP_NEIGHBORHOOD, Nodes: []uint64{1}},
+	); err == nil {
+		t.Errorf("k-hop query without a max depth is valid")
+	}
+	for _, maxDepth := range []float64{-1, 1.5, math.NaN(), math.Inf(1)} {
+		if _, err := NewVertexProgram(
+			Query{
+				QueryType: K_HOP_NEIGHBORHOOD, Nodes: []uint64{1},
+				Params: map[string]float64{K_HOP_MAX_DEPTH: maxDepth},
+			},
+		); err == nil {
+			t.Errorf("k-hop query with max depth %v is valid", maxDepth)
+		}
+	}
+}
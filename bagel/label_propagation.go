@@ -0,0 +1,138 @@
+package bagel
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// LABEL_PROPAGATION_MAX_SUPERSTEPS is the query parameter bounding the number
+// of supersteps of a label propagation query, which stops earlier if no
+// label changes
+const LABEL_PROPAGATION_MAX_SUPERSTEPS = "MaxSuperSteps"
+
+// defaultLabelPropagationSuperSteps is the number of supersteps of a label
+// propagation query that does not set LABEL_PROPAGATION_MAX_SUPERSTEPS
+const defaultLabelPropagationSuperSteps = 20
+
+// labelPropagationProgram detects communities with synchronous label
+// propagation, ignoring the direction of the edges. Every vertex starts
+// labelled with its own id and adopts the most frequent label among its
+// neighbors and itself, with ties going to the smallest label. Counting the
+// vertex's own label keeps two vertices from swapping labels forever. The
+// community of a vertex is its final label. There is no Combiner since
+// Compute keeps the latest label of every neighbor in PreviousValues
+type labelPropagationProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(LABEL_PROPAGATION, newLabelPropagationProgram)
+}
+
+func newLabelPropagationProgram(query Query) VertexProgram {
+	return &labelPropagationProgram{query: query}
+}
+
+func (p *labelPropagationProgram) Validate() error {
+	if len(p.query.Nodes) > 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	if !p.query.IsIntParam(
+		LABEL_PROPAGATION_MAX_SUPERSTEPS, defaultLabelPropagationSuperSteps,
+		1, math.MaxInt32,
+	) {
+		return errors.New("label propagation needs a whole number of supersteps")
+	}
+	return nil
+}
+
+func (p *labelPropagationProgram) maxSuperSteps() uint64 {
+	return uint64(
+		p.query.Param(
+			LABEL_PROPAGATION_MAX_SUPERSTEPS,
+			defaultLabelPropagationSuperSteps,
+		),
+	)
+}
+
+func (p *labelPropagationProgram) InitialValue(v *Vertex) interface{} {
+	return v.Id
+}
+
+func (p *labelPropagationProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	// every vertex sends its initial label at superstep 1
+	if v.SuperStepNum() <= 1 {
+		return p.sendLabel(v)
+	}
+
+	for _, message := range v.Messages {
+		v.PreviousValues[message.SourceVertexId] = message.Value
+	}
+
+	label := p.mostFrequentLabel(v)
+	if label == v.CurrentValue.(uint64) {
+		return nil
+	}
+	v.CurrentValue = label
+	if v.SuperStepNum() >= p.maxSuperSteps() {
+		return nil
+	}
+	return p.sendLabel(v)
+}
+
+// mostFrequentLabel returns the most frequent label among the vertex and its
+// neighbors, or the smallest of them if there is a tie
+func (p *labelPropagationProgram) mostFrequentLabel(v *Vertex) uint64 {
+	frequencies := map[uint64]int{v.CurrentValue.(uint64): 1}
+	for _, label := range v.PreviousValues {
+		frequencies[label.(uint64)]++
+	}
+
+	labels := make([]uint64, 0, len(frequencies))
+	for label := range frequencies {
+		labels = append(labels, label)
+	}
+	sort.Slice(
+		labels, func(i, j int) bool {
+			if frequencies[labels[i]] != frequencies[labels[j]] {
+				return frequencies[labels[i]] > frequencies[labels[j]]
+			}
+			return labels[i] < labels[j]
+		},
+	)
+	return labels[0]
+}
+
+// sendLabel sends the label of the vertex to its neighbors
+func (p *labelPropagationProgram) sendLabel(v *Vertex) []Message {
+	neighbors := v.UndirectedNeighbors()
+	result := make([]Message, 0, len(neighbors))
+	for _, neighborVertexId := range neighbors {
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          v.CurrentValue,
+			},
+		)
+	}
+	return result
+}
+
+func (p *labelPropagationProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	return partialComponentSizes(p.query, vertices)
+}
+
+// MergeResults returns the ComponentResult of the queried vertex's
+// community, or the ComponentHistogram of the community sizes if no vertex
+// is queried
+func (p *labelPropagationProgram) MergeResults(
+	partials []interface{},
+) interface{} {
+	return mergeComponentSizes(p.query, partials)
+}
@@ -0,0 +1,90 @@
+package bagel
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// two cliques, {1, 2, 3, 4} and {5, 6, 7, 8}, joined by the edge from 4 to 5
+var testCommunitiesGraph = map[uint64][]uint64{
+	1: {2, 3, 4},
+	2: {3, 4},
+	3: {4},
+	4: {5},
+	5: {6, 7, 8},
+	6: {7, 8},
+	7: {8},
+	8: {},
+}
+
+func TestLabelPropagationLabels(t *testing.T) {
+	w, _ := runTestQuery(
+		t, Query{QueryType: LABEL_PROPAGATION}, testCommunitiesGraph,
+	)
+
+	expected := map[uint64]uint64{
+		1: 1, 2: 1, 3: 1, 4: 1, 5: 5, 6: 5, 7: 5, 8: 5,
+	}
+	for id, label := range expected {
+		if w.Vertices[id].CurrentValue != label {
+			t.Errorf(
+				"vertex %v: expected community %v but got %v", id, label,
+				w.Vertices[id].CurrentValue,
+			)
+		}
+	}
+}
+
+func TestLabelPropagationCommunityOfVertex(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{QueryType: LABEL_PROPAGATION, Nodes: []uint64{7}},
+		testCommunitiesGraph,
+	)
+	expected := ComponentResult{ComponentId: 5, Size: 4}
+	if result != expected {
+		t.Errorf("expected community %v but got %v", expected, result)
+	}
+}
+
+func TestLabelPropagationMaxSuperSteps(t *testing.T) {
+	// labels only change once before the query stops
+	graph := map[uint64][]uint64{1: {2}, 2: {3}, 3: {4}, 4: {}}
+	w, result := runTestQuery(
+		t, Query{
+			QueryType: LABEL_PROPAGATION,
+			Params:    map[string]float64{LABEL_PROPAGATION_MAX_SUPERSTEPS: 2},
+		}, graph,
+	)
+
+	expected := map[uint64]uint64{1: 1, 2: 1, 3: 2, 4: 3}
+	for id, label := range expected {
+		if w.Vertices[id].CurrentValue != label {
+			t.Errorf(
+				"vertex %v: expected label %v but got %v", id, label,
+				w.Vertices[id].CurrentValue,
+			)
+		}
+	}
+	if !reflect.DeepEqual(result, ComponentHistogram{2: 1, 1: 2}) {
+		t.Errorf("unexpected community histogram %v", result)
+	}
+}
+
+func TestLabelPropagationValidation(t *testing.T) {
+	for _, maxSuperSteps := range []float64{0, -1, 2.5, math.NaN(), math.Inf(1)} {
+		if _, err := NewVertexProgram(
+			Query{
+				QueryType: LABEL_PROPAGATION,
+				Params: map[string]float64{
+					LABEL_PROPAGATION_MAX_SUPERSTEPS: maxSuperSteps,
+				},
+			},
+		); err == nil {
+			t.Errorf(
+				"label propagation query with %v supersteps is valid",
+				maxSuperSteps,
+			)
+		}
+	}
+}
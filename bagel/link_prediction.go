@@ -0,0 +1,277 @@
+package bagel
+
+import (
+	"encoding/gob"
+	"errors"
+	"math"
+	coordgRPC "project/bagel/proto/coord"
+)
+
+// LINK_PREDICTION_METRIC is the query parameter picking the score candidates
+// of a link prediction query are ranked by
+const LINK_PREDICTION_METRIC = "Metric"
+
+// constants are used as the LINK_PREDICTION_METRIC of a query
+const (
+	LINK_METRIC_JACCARD = iota
+	LINK_METRIC_ADAMIC_ADAR
+	LINK_METRIC_COMMON_NEIGHBORS
+)
+
+// LinkCandidate is an entry of the ranking returned by a link prediction
+// query, whose Score is the one of the query's metric
+type LinkCandidate struct {
+	RankedVertex
+	LinkScores
+}
+
+// LinkCandidates is the result of a link prediction query, best ranked first
+type LinkCandidates []LinkCandidate
+
+// Ranked returns the ranking of the candidates by the query's metric
+func (c LinkCandidates) Ranked() []RankedVertex {
+	ranking := make([]RankedVertex, 0, len(c))
+	for _, candidate := range c {
+		ranking = append(ranking, candidate.RankedVertex)
+	}
+	return ranking
+}
+
+// FillQueryResult sends every score of the candidates, their ranking being
+// sent as the Ranking of the query
+func (c LinkCandidates) FillQueryResult(reply *coordgRPC.QueryResult) {
+	for _, candidate := range c {
+		reply.LinkCandidates = append(
+			reply.LinkCandidates, &coordgRPC.LinkCandidate{
+				VertexId:        candidate.VertexId,
+				Jaccard:         candidate.Jaccard,
+				AdamicAdar:      candidate.AdamicAdar,
+				CommonNeighbors: uint64(candidate.CommonNeighbors),
+			},
+		)
+	}
+}
+
+// LinkScores is the value of a candidate for a link with the queried vertex
+type LinkScores struct {
+	// number of neighbors shared with the queried vertex
+	CommonNeighbors int
+	// sum of 1 / log(degree) of the common neighbors
+	AdamicAdar float64
+	// common neighbors over the neighbors of either vertex
+	Jaccard float64
+}
+
+// linkMessage is sent by the queried vertex to its neighbors with its
+// degree, and forwarded by them to their own neighbors with their share of
+// the scores
+type linkMessage struct {
+	SourceDegree    int
+	CommonNeighbors int
+	AdamicAdar      float64
+}
+
+// linkPredictionProgram scores the vertices within two hops of the queried
+// vertex as candidates for a link with it, ignoring the direction of the
+// edges. At superstep 1, the queried vertex sends its degree to its
+// neighbors, which get scores without common neighbors and forward it at
+// superstep 2 to their own neighbors along with 1 / log of their degree.
+// Every vertex receiving those messages at superstep 3 shares a neighbor
+// with the queried vertex for each of them, so that a neighbor also two
+// hops away is scored by its common neighbors like any other candidate
+type linkPredictionProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(LINK_PREDICTION, newLinkPredictionProgram)
+
+	// messages are sent, scores checkpointed and rankings sent to the coord
+	// as an interface{}
+	gob.Register(linkMessage{})
+	gob.Register(LinkScores{})
+	gob.Register(LinkCandidates{})
+}
+
+func newLinkPredictionProgram(query Query) VertexProgram {
+	return &linkPredictionProgram{query: query}
+}
+
+func (p *linkPredictionProgram) Validate() error {
+	if len(p.query.Nodes) != 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	if !p.query.IsTopKQuery() {
+		return errors.New("link prediction returns a ranking of candidates")
+	}
+	if !p.query.IsIntParam(
+		LINK_PREDICTION_METRIC, LINK_METRIC_JACCARD, LINK_METRIC_JACCARD,
+		LINK_METRIC_COMMON_NEIGHBORS,
+	) {
+		return errors.New("unknown link prediction metric")
+	}
+	return nil
+}
+
+func (p *linkPredictionProgram) metric() int {
+	return int(p.query.Param(LINK_PREDICTION_METRIC, LINK_METRIC_JACCARD))
+}
+
+// InitialValue is nil, since only the candidates get scores
+func (p *linkPredictionProgram) InitialValue(v *Vertex) interface{} {
+	return nil
+}
+
+func (p *linkPredictionProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	switch {
+	case v.SuperStepNum() <= 1:
+		if v.Id != p.query.Nodes[0] {
+			return nil
+		}
+		neighbors := v.UndirectedNeighbors()
+		return p.sendToNeighbors(
+			v, neighbors, linkMessage{SourceDegree: len(neighbors)},
+		)
+	case v.SuperStepNum() == 2 && len(v.Messages) > 0:
+		// the queried vertex is a neighbor, which leaves the vertex with
+		// a degree of at least 2 if it has another neighbor to forward to
+		v.CurrentValue = LinkScores{}
+		neighbors := v.UndirectedNeighbors()
+		return p.sendToNeighbors(
+			v, neighbors, linkMessage{
+				SourceDegree:    v.Messages[0].Value.(linkMessage).SourceDegree,
+				CommonNeighbors: 1,
+				AdamicAdar:      1 / math.Log(float64(len(neighbors))),
+			},
+		)
+	case v.SuperStepNum() == 3 && len(v.Messages) > 0:
+		p.score(v)
+	}
+	return nil
+}
+
+// sendToNeighbors sends the message to the neighbors of the vertex other
+// than the queried vertex
+func (p *linkPredictionProgram) sendToNeighbors(
+	v *Vertex, neighbors []uint64, value linkMessage,
+) []Message {
+	result := make([]Message, 0, len(neighbors))
+	for _, neighborVertexId := range neighbors {
+		if neighborVertexId == p.query.Nodes[0] {
+			continue
+		}
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          value,
+			},
+		)
+	}
+	return result
+}
+
+// score sets the scores of a vertex two hops away from the queried vertex,
+// whether or not it is also one of its neighbors
+func (p *linkPredictionProgram) score(v *Vertex) {
+	if v.Id == p.query.Nodes[0] {
+		return
+	}
+	neighbors := v.UndirectedNeighbors()
+
+	var total linkMessage
+	for _, message := range v.Messages {
+		value := message.Value.(linkMessage)
+		total.SourceDegree = value.SourceDegree
+		total.CommonNeighbors += value.CommonNeighbors
+		total.AdamicAdar += value.AdamicAdar
+	}
+	numNeighbors := total.SourceDegree + len(neighbors) -
+		total.CommonNeighbors
+	v.CurrentValue = LinkScores{
+		CommonNeighbors: total.CommonNeighbors,
+		AdamicAdar:      total.AdamicAdar,
+		Jaccard:         float64(total.CommonNeighbors) / float64(numNeighbors),
+	}
+}
+
+// Combine adds up the shares of the common neighbors
+func (p *linkPredictionProgram) Combine(a Message, b Message) Message {
+	aValue, bValue := a.Value.(linkMessage), b.Value.(linkMessage)
+	a.Value = linkMessage{
+		SourceDegree:    bValue.SourceDegree,
+		CommonNeighbors: aValue.CommonNeighbors + bValue.CommonNeighbors,
+		AdamicAdar:      aValue.AdamicAdar + bValue.AdamicAdar,
+	}
+	return a
+}
+
+// PartialResult ranks the worker's candidates by the query's metric
+func (p *linkPredictionProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	candidates := make([]LinkCandidate, 0)
+	for _, vertex := range vertices {
+		// vertices are only scored at the last superstep
+		scores, isCandidate := vertex.CurrentValue.(LinkScores)
+		if !isCandidate {
+			continue
+		}
+		candidates = append(
+			candidates, LinkCandidate{
+				RankedVertex: RankedVertex{
+					VertexId: vertex.Id, Score: p.metricScore(scores),
+				},
+				LinkScores: scores,
+			},
+		)
+	}
+	return p.topCandidates(candidates)
+}
+
+func (p *linkPredictionProgram) metricScore(scores LinkScores) float64 {
+	switch p.metric() {
+	case LINK_METRIC_ADAMIC_ADAR:
+		return scores.AdamicAdar
+	case LINK_METRIC_COMMON_NEIGHBORS:
+		return float64(scores.CommonNeighbors)
+	}
+	return scores.Jaccard
+}
+
+// topCandidates returns the TopK best ranked candidates, best first
+func (p *linkPredictionProgram) topCandidates(
+	candidates []LinkCandidate,
+) LinkCandidates {
+	ranked := make([]RankedVertex, 0, len(candidates))
+	scores := make(map[uint64]LinkScores, len(candidates))
+	for _, candidate := range candidates {
+		ranked = append(ranked, candidate.RankedVertex)
+		scores[candidate.VertexId] = candidate.LinkScores
+	}
+
+	ranking := topK(ranked, int(p.query.TopK))
+	result := make(LinkCandidates, 0, len(ranking))
+	for _, rankedVertex := range ranking {
+		result = append(
+			result, LinkCandidate{
+				RankedVertex: rankedVertex,
+				LinkScores:   scores[rankedVertex.VertexId],
+			},
+		)
+	}
+	return result
+}
+
+// MergeResults returns the TopK candidates of the graph
+func (p *linkPredictionProgram) MergeResults(
+	partials []interface{},
+) interface{} {
+	candidates := make([]LinkCandidate, 0)
+	for _, partial := range partials {
+		candidates = append(candidates, partial.(LinkCandidates)...)
+	}
+	return p.topCandidates(candidates)
+}
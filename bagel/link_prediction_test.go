@@ -0,0 +1,155 @@
+package bagel
+
+import (
+	"math"
+	"testing"
+)
+
+// linkPredictionGraph has the undirected edges 1-2, 1-3, 2-4, 2-6, 3-4 and
+// 3-5. Vertex 1 shares 2 and 3 with 4, 3 with 5 and 2 with 6
+var linkPredictionGraph = map[uint64][]uint64{
+	1: {2},
+	2: {4, 6},
+	3: {1},
+	4: {3},
+	5: {3},
+	6: {},
+	7: {},
+}
+
+func TestLinkPredictionScores(t *testing.T) {
+	w, result := runTestQuery(
+		t, Query{
+			QueryType: LINK_PREDICTION, Nodes: []uint64{1},
+			ResultMode: RESULT_TOP_K, TopK: 2,
+		}, linkPredictionGraph,
+	)
+
+	// 2 and 3 have a degree of 3
+	expected := []LinkCandidate{
+		{
+			RankedVertex: RankedVertex{VertexId: 4, Score: 1},
+			LinkScores: LinkScores{
+				CommonNeighbors: 2, AdamicAdar: 2 / math.Log(3), Jaccard: 1,
+			},
+		},
+		{
+			RankedVertex: RankedVertex{VertexId: 5, Score: 0.5},
+			LinkScores: LinkScores{
+				CommonNeighbors: 1, AdamicAdar: 1 / math.Log(3), Jaccard: 0.5,
+			},
+		},
+	}
+	candidates := result.(LinkCandidates)
+	if len(candidates) != len(expected) {
+		t.Fatalf("expected candidates %v but got %v", expected, candidates)
+	}
+	for idx := range expected {
+		if candidates[idx].VertexId != expected[idx].VertexId ||
+			candidates[idx].CommonNeighbors != expected[idx].CommonNeighbors ||
+			math.Abs(candidates[idx].Score-expected[idx].Score) > 1e-9 ||
+			math.Abs(candidates[idx].AdamicAdar-expected[idx].AdamicAdar) > 1e-9 ||
+			math.Abs(candidates[idx].Jaccard-expected[idx].Jaccard) > 1e-9 {
+			t.Errorf("expected candidates %v but got %v", expected, candidates)
+		}
+	}
+
+	// neighbors without common neighbors are candidates scoring 0
+	for _, id := range []uint64{2, 3} {
+		if w.Vertices[id].CurrentValue != (LinkScores{}) {
+			t.Errorf(
+				"expected vertex %v to score 0 but got %v", id,
+				w.Vertices[id].CurrentValue,
+			)
+		}
+	}
+
+	// the queried vertex and unreachable vertices are not candidates
+	for _, id := range []uint64{1, 7} {
+		if w.Vertices[id].CurrentValue != nil {
+			t.Errorf(
+				"vertex %v is a candidate with scores %v", id,
+				w.Vertices[id].CurrentValue,
+			)
+		}
+	}
+}
+
+func TestLinkPredictionMetric(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{
+			QueryType: LINK_PREDICTION, Nodes: []uint64{1},
+			ResultMode: RESULT_TOP_K, TopK: 5,
+			Params: map[string]float64{
+				LINK_PREDICTION_METRIC: LINK_METRIC_COMMON_NEIGHBORS,
+			},
+		}, linkPredictionGraph,
+	)
+
+	expected := []RankedVertex{
+		{VertexId: 4, Score: 2}, {VertexId: 5, Score: 1},
+		{VertexId: 6, Score: 1}, {VertexId: 2, Score: 0},
+		{VertexId: 3, Score: 0},
+	}
+	ranking := result.(LinkCandidates).Ranked()
+	if len(ranking) != len(expected) {
+		t.Fatalf("expected ranking %v but got %v", expected, ranking)
+	}
+	for idx := range expected {
+		if ranking[idx] != expected[idx] {
+			t.Errorf("expected ranking %v but got %v", expected, ranking)
+		}
+	}
+}
+
+func TestLinkPredictionNeighborCandidate(t *testing.T) {
+	// 2 is a neighbor of 1 and two hops away from it through 3
+	_, result := runTestQuery(
+		t, Query{
+			QueryType: LINK_PREDICTION, Nodes: []uint64{1},
+			ResultMode: RESULT_TOP_K, TopK: 1,
+		}, map[uint64][]uint64{1: {2, 3}, 2: {3}, 3: {}},
+	)
+
+	// 3 has a degree of 2, and 1 and 2 have the neighbors 1, 2 and 3
+	expected := LinkCandidate{
+		RankedVertex: RankedVertex{VertexId: 2, Score: 1.0 / 3},
+		LinkScores: LinkScores{
+			CommonNeighbors: 1, AdamicAdar: 1 / math.Log(2), Jaccard: 1.0 / 3,
+		},
+	}
+	candidates := result.(LinkCandidates)
+	if len(candidates) != 1 || candidates[0].VertexId != expected.VertexId ||
+		candidates[0].CommonNeighbors != expected.CommonNeighbors ||
+		math.Abs(candidates[0].AdamicAdar-expected.AdamicAdar) > 1e-9 ||
+		math.Abs(candidates[0].Jaccard-expected.Jaccard) > 1e-9 {
+		t.Errorf("expected candidates %v but got %v", expected, candidates)
+	}
+}
+
+func TestLinkPredictionValidation(t *testing.T) {
+	if _, err := NewVertexProgram(
+		Query{QueryType: LINK_PREDICTION, Nodes: []uint64{1}},
+	); err == nil {
+		t.Errorf("link prediction query for a target vertex is valid")
+	}
+	if _, err := NewVertexProgram(
+		Query{
+			QueryType: LINK_PREDICTION, Nodes: []uint64{1, 2},
+			ResultMode: RESULT_TOP_K, TopK: 10,
+		},
+	); err == nil {
+		t.Errorf("link prediction query for two vertices is valid")
+	}
+	for _, metric := range []float64{-1, 3, 0.5, math.NaN()} {
+		if _, err := NewVertexProgram(
+			Query{
+				QueryType: LINK_PREDICTION, Nodes: []uint64{1},
+				ResultMode: RESULT_TOP_K, TopK: 10,
+				Params: map[string]float64{LINK_PREDICTION_METRIC: metric},
+			},
+		); err == nil {
+			t.Errorf("link prediction query with metric %v is valid", metric)
+		}
+	}
+}
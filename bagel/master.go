@@ -0,0 +1,132 @@
+package bagel
+
+import (
+	"fmt"
+	"log"
+)
+
+// MasterState is the state a MasterComputer sets for a superstep
+type MasterState struct {
+	Phase string
+	// every vertex is computed at the first superstep of a phase, whether or
+	// not it received messages
+	IsNewPhase bool
+	Broadcast  map[string]float64
+	// the workers stop computing and report their results at the superstep
+	IsHalted bool
+}
+
+// MasterContext is what a MasterComputer sees of the query after a superstep
+// barrier, and records its decisions for the next superstep
+type MasterContext struct {
+	SuperStepNum uint64             // superstep that just completed
+	Aggregated   map[string]float64 // aggregator values of the superstep
+	// the superstep was the first of its phase, which computes every vertex
+	IsPhaseStart bool
+	// every vertex has voted to halt and no messages are in flight, so the
+	// query ends unless the MasterComputer starts a new phase
+	AllWorkersInactive bool
+	next               MasterState
+	decisions          []string
+}
+
+// Phase returns the current phase of the query
+func (m *MasterContext) Phase() string {
+	return m.next.Phase
+}
+
+// SetPhase moves the query to a new phase at the next superstep, which
+// computes every vertex and keeps the query running if all the workers are
+// inactive
+func (m *MasterContext) SetPhase(phase string) {
+	m.next.Phase = phase
+	m.next.IsNewPhase = true
+	m.decisions = append(m.decisions, "phase "+phase)
+}
+
+// Broadcast makes the value visible to every vertex through
+// Vertex.BroadcastValue from the next superstep on
+func (m *MasterContext) Broadcast(name string, value float64) {
+	broadcast := make(map[string]float64, len(m.next.Broadcast)+1)
+	for existingName, existingValue := range m.next.Broadcast {
+		broadcast[existingName] = existingValue
+	}
+	broadcast[name] = value
+	m.next.Broadcast = broadcast
+	m.decisions = append(
+		m.decisions, fmt.Sprintf("broadcast %v=%v", name, value),
+	)
+}
+
+// BroadcastValue returns the named value broadcast for the next superstep,
+// and false if it was never broadcast
+func (m *MasterContext) BroadcastValue(name string) (float64, bool) {
+	value, exists := m.next.Broadcast[name]
+	return value, exists
+}
+
+// Halt ends the query after the next superstep, in which the workers only
+// report their results
+func (m *MasterContext) Halt() {
+	m.next.IsHalted = true
+	m.decisions = append(m.decisions, "halt")
+}
+
+// masterCompute runs the MasterComputer of the query's program after the
+// barrier of superStepNum, and returns the master state of the next superstep
+func (c *Coord) masterCompute(
+	superStepNum uint64, result superstepDone, logger *log.Logger,
+) MasterState {
+	next := MasterState{
+		Phase:     c.masterState.Phase,
+		Broadcast: c.masterState.Broadcast,
+	}
+	masterComputer, ok := c.program.(MasterComputer)
+	if !ok {
+		return next
+	}
+
+	master := &MasterContext{
+		SuperStepNum:       superStepNum,
+		Aggregated:         result.aggregated,
+		IsPhaseStart:       superStepNum == 1 || c.masterState.IsNewPhase,
+		AllWorkersInactive: c.isQueryDone(result),
+		next:               next,
+	}
+	masterComputer.MasterCompute(master)
+
+	if len(master.decisions) > 0 {
+		logger.Printf(
+			"MasterCompute after superstep %v: %v\n", superStepNum,
+			master.decisions,
+		)
+	}
+	return master.next
+}
+
+// Phase returns the phase of the query set by the program's MasterComputer
+func (v *Vertex) Phase() string {
+	if v.superStep == nil {
+		return ""
+	}
+	return v.superStep.master.Phase
+}
+
+// IsPhaseStart returns true at superstep 1 and at the first superstep of
+// every phase set by the program's MasterComputer
+func (v *Vertex) IsPhaseStart() bool {
+	if v.superStep == nil {
+		return false
+	}
+	return v.superStep.superStepNum == 1 || v.superStep.master.IsNewPhase
+}
+
+// BroadcastValue returns the named value broadcast by the program's
+// MasterComputer, and false if it was never broadcast
+func (v *Vertex) BroadcastValue(name string) (float64, bool) {
+	if v.superStep == nil {
+		return 0, false
+	}
+	value, exists := v.superStep.master.Broadcast[name]
+	return value, exists
+}
@@ -0,0 +1,125 @@
+package bagel
+
+import (
+	"io"
+	"log"
+	"reflect"
+	"testing"
+)
+
+const (
+	testPhases             = "TestPhases"
+	testPhaseNormalize     = "normalize"
+	testNumVertices        = "NumVertices"
+	testMaxPhaseSuperSteps = 5
+)
+
+// phasesTestProgram counts the vertices in its first phase, then sets every
+// vertex to its share of the graph in a second phase started by the master
+type phasesTestProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(
+		testPhases, func(query Query) VertexProgram {
+			return &phasesTestProgram{query: query}
+		},
+	)
+}
+
+func (p *phasesTestProgram) Validate() error {
+	return nil
+}
+
+func (p *phasesTestProgram) InitialValue(v *Vertex) interface{} {
+	return 0.0
+}
+
+func (p *phasesTestProgram) InitialMessages(v *Vertex) []Message {
+	return []Message{{v.Id, v.Id, nil}}
+}
+
+func (p *phasesTestProgram) Compute(v *Vertex) []Message {
+	switch v.Phase() {
+	case "":
+		v.Aggregate(testNumVertices, 1)
+	case testPhaseNormalize:
+		numVertices, _ := v.BroadcastValue(testNumVertices)
+		v.CurrentValue = 1 / numVertices
+	}
+	v.VoteToHalt()
+	return nil
+}
+
+func (p *phasesTestProgram) Aggregators() map[string]string {
+	return map[string]string{testNumVertices: AGGREGATE_COUNT}
+}
+
+func (p *phasesTestProgram) MasterCompute(master *MasterContext) {
+	if master.Phase() == "" && master.AllWorkersInactive {
+		master.Broadcast(testNumVertices, master.Aggregated[testNumVertices])
+		master.SetPhase(testPhaseNormalize)
+	}
+	if master.SuperStepNum >= testMaxPhaseSuperSteps {
+		master.Halt()
+	}
+}
+
+func TestMasterComputePhases(t *testing.T) {
+	graph := map[uint64][]uint64{1: {2}, 2: {3}, 3: {}, 4: {}}
+	w, _ := runTestQuery(t, Query{QueryType: testPhases}, graph)
+
+	var result VertexValuesResult
+	w.GetVertexValues(VertexValuesRequest{}, &result)
+	expected := map[uint64]float64{1: 0.25, 2: 0.25, 3: 0.25, 4: 0.25}
+	if !reflect.DeepEqual(result.Values, expected) {
+		t.Errorf("expected vertex values %v but got %v", expected, result.Values)
+	}
+}
+
+func TestMasterComputeHalt(t *testing.T) {
+	c := &Coord{program: &phasesTestProgram{}}
+	c.masterState = MasterState{
+		Phase:     testPhaseNormalize,
+		Broadcast: map[string]float64{testNumVertices: 4},
+	}
+
+	next := c.masterCompute(
+		testMaxPhaseSuperSteps, superstepDone{}, log.New(io.Discard, "", 0),
+	)
+	if !next.IsHalted || next.IsNewPhase || next.Phase != testPhaseNormalize {
+		t.Errorf("expected the query to halt in its phase but got %v", next)
+	}
+	if next.Broadcast[testNumVertices] != 4 {
+		t.Errorf("broadcast values were not kept: %v", next.Broadcast)
+	}
+}
+
+func TestHaltedSuperStepOnlyReportsResults(t *testing.T) {
+	graph := map[uint64][]uint64{1: {2}, 2: {1}}
+	w, _ := runTestQuery(
+		t, Query{QueryType: PAGE_RANK, Nodes: []uint64{1}}, graph,
+	)
+
+	// the queued messages of a halted superstep are never computed
+	w.NextSuperStep.Messages = map[uint64][]Message{1: {{2, 1, 1.0}}}
+	var resp ProgressSuperStepResult
+	err := w.ComputeVertices(
+		&ProgressSuperStep{
+			SuperStepNum: 1, Master: MasterState{IsHalted: true},
+		}, &resp,
+	)
+	if err != nil {
+		t.Fatalf("halted superstep failed: %v", err)
+	}
+	if resp.IsActive {
+		t.Errorf("worker is active after a halted superstep")
+	}
+	if resp.CurrentValue != w.Vertices[1].CurrentValue {
+		t.Errorf(
+			"expected result %v but got %v", w.Vertices[1].CurrentValue,
+			resp.CurrentValue,
+		)
+	}
+}
@@ -0,0 +1,244 @@
+package bagel
+
+import (
+	"log"
+	"sort"
+)
+
+// constants are used as the Type of a mutation, in the order mutations are
+// applied at the barrier
+const (
+	MUTATION_REMOVE_EDGE = iota
+	MUTATION_REMOVE_VERTEX
+	MUTATION_ADD_VERTEX
+	MUTATION_ADD_EDGE
+)
+
+// Mutation is a change to the topology of the graph requested by a vertex
+// during Compute. Vertex mutations only use SourceVertexId
+type Mutation struct {
+	Type           int
+	SourceVertexId uint64
+	DestVertexId   uint64
+	Weight         float64
+}
+
+// AddVertex requests a vertex with no edges, whose value is the program's
+// InitialValue. Adding a vertex that exists does nothing
+func (v *Vertex) AddVertex(id uint64) {
+	v.requestMutation(Mutation{Type: MUTATION_ADD_VERTEX, SourceVertexId: id})
+}
+
+// RemoveVertex requests the removal of a vertex with its out-edges. The
+// edges of other vertices to it, and the in-edges its out-edges leave at
+// their destinations, are only removed by RemoveEdge
+func (v *Vertex) RemoveVertex(id uint64) {
+	v.requestMutation(
+		Mutation{Type: MUTATION_REMOVE_VERTEX, SourceVertexId: id},
+	)
+}
+
+// AddEdge requests an edge from source to dest. Adding an edge that exists
+// keeps the lighter of the two weights, and edges from a vertex that does
+// not exist are dropped
+func (v *Vertex) AddEdge(source uint64, dest uint64, weight float64) {
+	v.requestMutation(
+		Mutation{
+			Type: MUTATION_ADD_EDGE, SourceVertexId: source,
+			DestVertexId: dest, Weight: weight,
+		},
+	)
+}
+
+// RemoveEdge requests the removal of the edges from source to dest
+func (v *Vertex) RemoveEdge(source uint64, dest uint64) {
+	v.requestMutation(
+		Mutation{
+			Type: MUTATION_REMOVE_EDGE, SourceVertexId: source,
+			DestVertexId: dest,
+		},
+	)
+}
+
+// requestMutation records a mutation of the superstep, which the worker
+// sends with the messages and applies before the next superstep
+func (v *Vertex) requestMutation(mutation Mutation) {
+	if v.superStep == nil {
+		return
+	}
+	v.superStep.mutations = append(v.superStep.mutations, mutation)
+}
+
+// isEdgeMutation reports whether the mutation changes the out-edges of its
+// source and the in-edges of its destination
+func (m Mutation) isEdgeMutation() bool {
+	return m.Type == MUTATION_ADD_EDGE || m.Type == MUTATION_REMOVE_EDGE
+}
+
+// mapMutationsToWorkers queues the mutations for the workers holding the
+// vertices they change, with the partitioning of the messages. Edge
+// mutations go to the partitions of both of their endpoints
+func (w *Worker) mapMutationsToWorkers(mutations []Mutation) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	for _, mutation := range mutations {
+		sourceWorker := workerIdForVertex(mutation.SourceVertexId, w.NumWorkers)
+		w.SuperStep.OutgoingMutations[sourceWorker] = append(
+			w.SuperStep.OutgoingMutations[sourceWorker], mutation,
+		)
+		if !mutation.isEdgeMutation() {
+			continue
+		}
+		destWorker := workerIdForVertex(mutation.DestVertexId, w.NumWorkers)
+		if destWorker != sourceWorker {
+			w.SuperStep.OutgoingMutations[destWorker] = append(
+				w.SuperStep.OutgoingMutations[destWorker], mutation,
+			)
+		}
+	}
+}
+
+// applyMutations changes the worker's partition with the mutations received
+// for the superstep. The result does not depend on the order the mutations
+// arrived in: edge removals are applied first, then vertex removals, vertex
+// additions and edge additions, each in order of vertex ids. A vertex removed
+// and added at the same superstep is added again with its initial value. The
+// caller must hold workerMutex
+func (w *Worker) applyMutations(mutations []Mutation) {
+	if len(mutations) == 0 {
+		return
+	}
+
+	sorted := append([]Mutation(nil), mutations...)
+	sort.Slice(
+		sorted, func(i, j int) bool {
+			a, b := sorted[i], sorted[j]
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			if a.SourceVertexId != b.SourceVertexId {
+				return a.SourceVertexId < b.SourceVertexId
+			}
+			if a.DestVertexId != b.DestVertexId {
+				return a.DestVertexId < b.DestVertexId
+			}
+			return a.Weight < b.Weight
+		},
+	)
+
+	for _, mutation := range sorted {
+		switch mutation.Type {
+		case MUTATION_REMOVE_EDGE:
+			w.removeEdge(mutation.SourceVertexId, mutation.DestVertexId)
+		case MUTATION_REMOVE_VERTEX:
+			delete(w.Vertices, mutation.SourceVertexId)
+		case MUTATION_ADD_VERTEX:
+			w.addVertex(mutation.SourceVertexId)
+		case MUTATION_ADD_EDGE:
+			w.addEdge(
+				mutation.SourceVertexId, mutation.DestVertexId,
+				mutation.Weight,
+			)
+		}
+	}
+	log.Printf(
+		"applyMutations: worker %v applied %v mutations\n",
+		w.config.WorkerId, len(sorted),
+	)
+}
+
+// removeEdge removes the edges from source to dest from the worker's
+// vertices. Mutations replace the edge lists of the vertices rather than
+// modifying them, since checkpoints share them
+func (w *Worker) removeEdge(source uint64, dest uint64) {
+	if vertex, exists := w.Vertices[source]; exists {
+		neighbors := make([]uint64, 0, len(vertex.Neighbors))
+		var weights []float64
+		for idx, neighborVertexId := range vertex.Neighbors {
+			if neighborVertexId == dest {
+				continue
+			}
+			neighbors = append(neighbors, neighborVertexId)
+			if len(vertex.EdgeWeights) > 0 {
+				weights = append(weights, vertex.EdgeWeight(idx))
+			}
+		}
+		vertex.Neighbors = neighbors
+		vertex.EdgeWeights = weights
+	}
+
+	if vertex, exists := w.Vertices[dest]; exists {
+		inNeighbors := make([]uint64, 0, len(vertex.InNeighbors))
+		for _, inNeighborVertexId := range vertex.InNeighbors {
+			if inNeighborVertexId != source {
+				inNeighbors = append(inNeighbors, inNeighborVertexId)
+			}
+		}
+		vertex.InNeighbors = inNeighbors
+	}
+}
+
+func (w *Worker) addVertex(id uint64) {
+	if _, exists := w.Vertices[id]; exists {
+		return
+	}
+	vertex := NewVertex(id, []uint64{})
+	vertex.CurrentValue = w.program.InitialValue(vertex)
+	w.Vertices[id] = vertex
+}
+
+func (w *Worker) addEdge(source uint64, dest uint64, weight float64) {
+	if vertex, exists := w.Vertices[source]; exists {
+		w.addOutEdge(vertex, dest, weight)
+	}
+
+	vertex, exists := w.Vertices[dest]
+	if !exists {
+		return
+	}
+	for _, inNeighborVertexId := range vertex.InNeighbors {
+		if inNeighborVertexId == source {
+			return
+		}
+	}
+	vertex.InNeighbors = append(
+		append([]uint64(nil), vertex.InNeighbors...), source,
+	)
+}
+
+// addOutEdge adds the edge to dest to the vertex, or lowers the weight of
+// the existing edge. The weights of an unweighted vertex are only stored
+// once an edge weighs something else than 1
+func (w *Worker) addOutEdge(vertex *Vertex, dest uint64, weight float64) {
+	weights := vertex.EdgeWeights
+	if len(weights) == 0 && weight != 1 {
+		weights = make([]float64, len(vertex.Neighbors))
+		for idx := range weights {
+			weights[idx] = 1
+		}
+	} else {
+		weights = append([]float64(nil), weights...)
+	}
+
+	for idx, neighborVertexId := range vertex.Neighbors {
+		if neighborVertexId != dest || vertex.EdgeWeight(idx) <= weight {
+			continue
+		}
+		weights[idx] = weight
+		vertex.EdgeWeights = weights
+		return
+	}
+	for _, neighborVertexId := range vertex.Neighbors {
+		if neighborVertexId == dest {
+			return
+		}
+	}
+
+	vertex.Neighbors = append(
+		append([]uint64(nil), vertex.Neighbors...), dest,
+	)
+	if len(weights) > 0 {
+		vertex.EdgeWeights = append(weights, weight)
+	}
+}
@@ -0,0 +1,182 @@
+package bagel
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"log"
+	"os"
+	"project/database/mongodb"
+	"reflect"
+	"testing"
+)
+
+const testMutation = "TestMutation"
+
+// mutationTestProgram replaces the edge 1 -> 2 with an edge 1 -> 4 to a new
+// vertex and removes vertex 3 at superstep 1. The value of a vertex is the
+// number of supersteps it was computed at
+type mutationTestProgram struct{}
+
+func init() {
+	RegisterVertexProgram(
+		testMutation, func(query Query) VertexProgram {
+			return &mutationTestProgram{}
+		},
+	)
+}
+
+func (p *mutationTestProgram) Validate() error {
+	return nil
+}
+
+func (p *mutationTestProgram) InitialValue(v *Vertex) interface{} {
+	return 0
+}
+
+func (p *mutationTestProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	v.CurrentValue = v.CurrentValue.(int) + 1
+	if v.Id == 1 && v.SuperStepNum() == 1 {
+		v.AddVertex(4)
+		v.AddEdge(1, 4, 2)
+		v.RemoveEdge(1, 2)
+		v.RemoveVertex(3)
+	}
+	return nil
+}
+
+var testMutationGraph = []mongodb.Vertex{
+	{ID: 1, Edges: []uint64{2, 3}},
+	{ID: 2, Edges: []uint64{}, InEdges: []uint64{1}},
+	{ID: 3, Edges: []uint64{1}, InEdges: []uint64{1}},
+}
+
+func TestMutationsAppliedAtNextSuperStep(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	w := newTestWorker(t, Query{QueryType: testMutation}, testMutationGraph)
+	var resp ProgressSuperStepResult
+	w.ComputeVertices(&ProgressSuperStep{SuperStepNum: 1}, &resp)
+	if len(w.Vertices) != 3 || !reflect.DeepEqual(
+		w.Vertices[1].Neighbors, []uint64{2, 3},
+	) {
+		t.Errorf("mutations were applied during superstep 1")
+	}
+	if !resp.IsActive {
+		t.Errorf("worker is inactive with mutations to apply")
+	}
+
+	w.ComputeVertices(&ProgressSuperStep{SuperStepNum: 2}, &resp)
+	if _, exists := w.Vertices[3]; exists {
+		t.Errorf("vertex 3 was not removed")
+	}
+	if !reflect.DeepEqual(w.Vertices[1].Neighbors, []uint64{3, 4}) ||
+		!reflect.DeepEqual(w.Vertices[1].EdgeWeights, []float64{1, 2}) {
+		t.Errorf(
+			"unexpected edges %v with weights %v", w.Vertices[1].Neighbors,
+			w.Vertices[1].EdgeWeights,
+		)
+	}
+	if len(w.Vertices[2].InNeighbors) != 0 ||
+		!reflect.DeepEqual(w.Vertices[4].InNeighbors, []uint64{1}) {
+		t.Errorf("in-edges were not updated")
+	}
+
+	// the new vertex is active at the superstep it is added
+	if w.Vertices[4].CurrentValue != 1 || resp.IsActive {
+		t.Errorf(
+			"vertex 4 computed %v supersteps, worker active: %v",
+			w.Vertices[4].CurrentValue, resp.IsActive,
+		)
+	}
+}
+
+func TestApplyMutationsResolvesConflicts(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	mutations := []Mutation{
+		{Type: MUTATION_ADD_EDGE, SourceVertexId: 1, DestVertexId: 5, Weight: 3},
+		{Type: MUTATION_ADD_EDGE, SourceVertexId: 1, DestVertexId: 5, Weight: 0.5},
+		{Type: MUTATION_ADD_EDGE, SourceVertexId: 6, DestVertexId: 1, Weight: 1},
+		{Type: MUTATION_ADD_VERTEX, SourceVertexId: 5},
+		{Type: MUTATION_ADD_VERTEX, SourceVertexId: 5},
+		{Type: MUTATION_REMOVE_VERTEX, SourceVertexId: 2},
+		{Type: MUTATION_ADD_VERTEX, SourceVertexId: 2},
+		{Type: MUTATION_REMOVE_EDGE, SourceVertexId: 1, DestVertexId: 3},
+		{Type: MUTATION_ADD_EDGE, SourceVertexId: 1, DestVertexId: 3, Weight: 1},
+	}
+
+	// the same mutations in any order give the same graph
+	var graphs []map[uint64]Vertex
+	for _, order := range [][]Mutation{mutations, reverseMutations(mutations)} {
+		w := newTestWorker(t, Query{QueryType: testMutation}, testMutationGraph)
+		w.Vertices[2].CurrentValue = 7
+		w.applyMutations(order)
+
+		graph := make(map[uint64]Vertex)
+		for id, vertex := range w.Vertices {
+			graph[id] = Vertex{
+				Neighbors:    vertex.Neighbors,
+				InNeighbors:  vertex.InNeighbors,
+				EdgeWeights:  vertex.EdgeWeights,
+				CurrentValue: vertex.CurrentValue,
+			}
+		}
+		graphs = append(graphs, graph)
+	}
+	if !reflect.DeepEqual(graphs[0], graphs[1]) {
+		t.Errorf("mutations applied in a different order: %v", graphs)
+	}
+
+	// the edge removed and added again is kept, the lighter edge to 5 wins,
+	// 2 is added again with its initial value and 6 does not exist
+	graph := graphs[0]
+	if !reflect.DeepEqual(graph[1].Neighbors, []uint64{2, 3, 5}) ||
+		!reflect.DeepEqual(graph[1].EdgeWeights, []float64{1, 1, 0.5}) {
+		t.Errorf(
+			"unexpected edges %v with weights %v", graph[1].Neighbors,
+			graph[1].EdgeWeights,
+		)
+	}
+	if graph[2].CurrentValue != 0 || len(graph[2].InNeighbors) != 0 {
+		t.Errorf("vertex 2 was not added again: %+v", graph[2])
+	}
+	if _, exists := graph[6]; exists {
+		t.Errorf("vertex 6 was added by an edge")
+	}
+}
+
+func reverseMutations(mutations []Mutation) []Mutation {
+	reversed := make([]Mutation, 0, len(mutations))
+	for idx := len(mutations) - 1; idx >= 0; idx-- {
+		reversed = append(reversed, mutations[idx])
+	}
+	return reversed
+}
+
+func TestMutationsAreCheckpointed(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	w := newTestWorker(t, Query{QueryType: testMutation}, testMutationGraph)
+	var resp ProgressSuperStepResult
+	w.ComputeVertices(&ProgressSuperStep{SuperStepNum: 1}, &resp)
+
+	// the checkpoint of superstep 2 holds the mutations it applies
+	checkpoint := w.checkpoint(2)
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(checkpoint.NextSuperStepState); err != nil {
+		t.Fatalf("could not encode the checkpoint: %v", err)
+	}
+	var restored SuperStep
+	if err := gob.NewDecoder(&buf).Decode(&restored); err != nil {
+		t.Fatalf("could not decode the checkpoint: %v", err)
+	}
+	if len(restored.Mutations) != 4 {
+		t.Errorf("checkpoint holds mutations %v", restored.Mutations)
+	}
+}
@@ -0,0 +1,212 @@
+package bagel
+
+import (
+	"errors"
+	"math"
+)
+
+// PAGE_RANK_DELTA is the aggregator holding the L1 norm of the change in
+// PageRank values at a superstep
+const PAGE_RANK_DELTA = "PageRankDelta"
+
+// PAGE_RANK_DANGLING is the aggregator holding the change in the flow of the
+// vertices without out-edges at a superstep, and the broadcast value holding
+// the share of that flow every seed vertex receives
+const PAGE_RANK_DANGLING = "PageRankDangling"
+
+// PAGE_RANK_SEEDS is the aggregator counting the seed vertices, broadcast
+// for the dangling flow to be split between them
+const PAGE_RANK_SEEDS = "PageRankSeeds"
+
+// query parameters of PageRank queries
+const (
+	// PAGE_RANK_DAMPING is the probability that a random surfer follows an
+	// out-edge rather than teleporting to a seed vertex
+	PAGE_RANK_DAMPING = "Damping"
+	// PAGE_RANK_TOLERANCE is the smallest change in the PageRank of a vertex
+	// that is sent to its neighbors
+	PAGE_RANK_TOLERANCE = "Tolerance"
+	// PAGE_RANK_MAX_ITERATIONS is the superstep after which vertices stop
+	// sending their PageRank
+	PAGE_RANK_MAX_ITERATIONS = "MaxIterations"
+)
+
+// defaultPageRankDamping is the damping factor of a PageRank query that does
+// not set PAGE_RANK_DAMPING
+const defaultPageRankDamping = 0.85
+
+// pageRankProgram has no Combiner since Compute keeps the latest flow from
+// every source vertex in PreviousValues, and only adds up those flows. The
+// flow of vertices without out-edges is redistributed to the seeds through
+// the MasterComputer, so the PageRanks add up to the number of seeds
+type pageRankProgram struct {
+	query Query
+	// seeds are the only vertices teleported to, or nil if every vertex is
+	seeds map[uint64]bool
+}
+
+func init() {
+	RegisterVertexProgram(PAGE_RANK, newPageRankProgram)
+}
+
+func newPageRankProgram(query Query) VertexProgram {
+	return &pageRankProgram{query: query}
+}
+
+func (p *pageRankProgram) Validate() error {
+	if err := p.validateParams(); err != nil {
+		return err
+	}
+	if !p.query.IsTargetQuery() {
+		return nil
+	}
+	if len(p.query.Nodes) != 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	return nil
+}
+
+// validateParams checks the PageRank parameters set by the query
+func (p *pageRankProgram) validateParams() error {
+	if damping := p.damping(); math.IsNaN(damping) || damping < 0 ||
+		damping >= 1 {
+		return errors.New("pagerank damping factor must be in [0, 1)")
+	}
+	if tolerance := p.tolerance(); math.IsNaN(tolerance) || tolerance <= 0 {
+		return errors.New("pagerank tolerance must be positive")
+	}
+	if !p.query.IsIntParam(
+		PAGE_RANK_MAX_ITERATIONS, MAX_ITERATIONS, 1, math.MaxInt32,
+	) {
+		return errors.New("pagerank needs a whole number of iterations")
+	}
+	return nil
+}
+
+func (p *pageRankProgram) damping() float64 {
+	return p.query.Param(PAGE_RANK_DAMPING, defaultPageRankDamping)
+}
+
+func (p *pageRankProgram) tolerance() float64 {
+	return p.query.Param(PAGE_RANK_TOLERANCE, EPSILON)
+}
+
+func (p *pageRankProgram) maxIterations() uint64 {
+	return uint64(p.query.Param(PAGE_RANK_MAX_ITERATIONS, MAX_ITERATIONS))
+}
+
+func (p *pageRankProgram) InitialValue(v *Vertex) interface{} {
+	return float64(0)
+}
+
+// isSeed reports whether the vertex is teleported to
+func (p *pageRankProgram) isSeed(v *Vertex) bool {
+	return p.seeds == nil || p.seeds[v.Id]
+}
+
+// InitialMessages starts the flow from the seeds, since the other vertices
+// only receive flow from their in-neighbors
+func (p *pageRankProgram) InitialMessages(v *Vertex) []Message {
+	if !p.isSeed(v) {
+		return nil
+	}
+	return []Message{{INITIALIZATION_VERTEX, v.Id, p.damping()}}
+}
+
+func (p *pageRankProgram) Compute(v *Vertex) []Message {
+	// the PageRank only changes when the flow from a neighbor or the
+	// dangling flow changes, which starts a new phase
+	if len(v.Messages) == 0 && !v.IsPhaseStart() {
+		v.VoteToHalt()
+		return nil
+	}
+
+	damping := p.damping()
+	totalFlow := 0.0
+	if p.isSeed(v) {
+		if v.SuperStepNum() == 1 {
+			v.Aggregate(PAGE_RANK_SEEDS, 1)
+		}
+		danglingFlow, _ := v.BroadcastValue(PAGE_RANK_DANGLING)
+		totalFlow = 1 - damping + danglingFlow
+	}
+
+	// update flow values
+	for _, message := range v.Messages {
+		flowValue := message.Value.(float64) // cast to an int
+		if message.SourceVertexId == INITIALIZATION_VERTEX {
+			totalFlow += flowValue
+		} else {
+			v.PreviousValues[message.SourceVertexId] = flowValue
+		}
+	}
+
+	// calculate new value
+	for _, flowValue := range v.PreviousValues {
+		totalFlow += flowValue.(float64)
+	}
+
+	delta := math.Abs(totalFlow - v.CurrentValue.(float64))
+	v.Aggregate(PAGE_RANK_DELTA, delta)
+
+	// update neighbors at next step if the change is large enough, until the
+	// query runs out of iterations
+	result := make([]Message, 0)
+	if delta > p.tolerance() {
+		// the flow of a dangling vertex goes to the seeds instead
+		if len(v.Neighbors) == 0 {
+			v.Aggregate(
+				PAGE_RANK_DANGLING,
+				damping*(totalFlow-v.CurrentValue.(float64)),
+			)
+		}
+		v.CurrentValue = totalFlow
+		if v.SuperStepNum() < p.maxIterations() {
+			for _, neighborVertexId := range v.Neighbors {
+				newMessage := Message{
+					SourceVertexId: v.Id,
+					DestVertexId:   neighborVertexId,
+					Value: damping * totalFlow /
+						float64(len(v.Neighbors)),
+				}
+				result = append(result, newMessage)
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		v.VoteToHalt()
+	}
+	return result
+}
+
+func (p *pageRankProgram) Aggregators() map[string]string {
+	return map[string]string{
+		PAGE_RANK_DELTA:    AGGREGATE_SUM,
+		PAGE_RANK_DANGLING: AGGREGATE_SUM,
+		PAGE_RANK_SEEDS:    AGGREGATE_COUNT,
+	}
+}
+
+// MasterCompute splits the change in the dangling flow between the seeds,
+// and computes every vertex at the next superstep for the seeds to take
+// their share
+func (p *pageRankProgram) MasterCompute(master *MasterContext) {
+	if master.SuperStepNum == 1 {
+		master.Broadcast(PAGE_RANK_SEEDS, master.Aggregated[PAGE_RANK_SEEDS])
+	}
+
+	change := master.Aggregated[PAGE_RANK_DANGLING]
+	numSeeds, _ := master.BroadcastValue(PAGE_RANK_SEEDS)
+	if change == 0 || numSeeds == 0 ||
+		master.SuperStepNum >= p.maxIterations() {
+		return
+	}
+	danglingFlow, _ := master.BroadcastValue(PAGE_RANK_DANGLING)
+	master.Broadcast(PAGE_RANK_DANGLING, danglingFlow+change/numSeeds)
+	master.SetPhase(master.Phase())
+}
+
+func (p *pageRankProgram) Result(v *Vertex) (interface{}, bool) {
+	return v.CurrentValue, IsTargetVertex(v.Id, p.query.Nodes, PAGE_RANK)
+}
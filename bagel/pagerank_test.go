@@ -0,0 +1,78 @@
+package bagel
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPageRankRedistributesDanglingFlow(t *testing.T) {
+	// 3 has no out-edges
+	graph := map[uint64][]uint64{1: {2}, 2: {1, 3}, 3: {}}
+	w, _ := runTestQuery(
+		t, Query{
+			QueryType: PAGE_RANK, Nodes: []uint64{1},
+			Params: map[string]float64{PAGE_RANK_TOLERANCE: 1e-4},
+		}, graph,
+	)
+
+	total := 0.0
+	for _, vertex := range w.Vertices {
+		total += vertex.CurrentValue.(float64)
+	}
+	if math.Abs(total-float64(len(graph))) > 1e-2 {
+		t.Errorf("expected PageRanks adding up to %v but got %v", len(graph), total)
+	}
+}
+
+func TestPageRankDampingParam(t *testing.T) {
+	// without damping, every vertex only gets its teleport flow
+	graph := map[uint64][]uint64{1: {2}, 2: {3}, 3: {}}
+	w, _ := runTestQuery(
+		t, Query{
+			QueryType: PAGE_RANK, Nodes: []uint64{1},
+			Params: map[string]float64{PAGE_RANK_DAMPING: 0},
+		}, graph,
+	)
+	for id, vertex := range w.Vertices {
+		if !almostEqual(vertex.CurrentValue.(float64), 1) {
+			t.Errorf("vertex %v has PageRank %v", id, vertex.CurrentValue)
+		}
+	}
+}
+
+func TestPageRankMaxIterationsParam(t *testing.T) {
+	// 3 updates its PageRank at superstep 2 but does not send it to 1
+	graph := map[uint64][]uint64{1: {3}, 2: {3}, 3: {1}}
+	w, _ := runTestQuery(
+		t, Query{
+			QueryType: PAGE_RANK, Nodes: []uint64{1},
+			Params: map[string]float64{PAGE_RANK_MAX_ITERATIONS: 2},
+		}, graph,
+	)
+	if !almostEqual(w.Vertices[3].CurrentValue.(float64), 1.85) {
+		t.Errorf("vertex 3 has PageRank %v", w.Vertices[3].CurrentValue)
+	}
+	if !almostEqual(w.Vertices[1].CurrentValue.(float64), 1) {
+		t.Errorf("vertex 1 has PageRank %v", w.Vertices[1].CurrentValue)
+	}
+}
+
+func TestPageRankParamsValidation(t *testing.T) {
+	for _, params := range []map[string]float64{
+		{PAGE_RANK_DAMPING: 1},
+		{PAGE_RANK_DAMPING: -0.5},
+		{PAGE_RANK_DAMPING: math.NaN()},
+		{PAGE_RANK_TOLERANCE: 0},
+		{PAGE_RANK_TOLERANCE: math.NaN()},
+		{PAGE_RANK_MAX_ITERATIONS: 0},
+		{PAGE_RANK_MAX_ITERATIONS: -1},
+		{PAGE_RANK_MAX_ITERATIONS: 2.5},
+		{PAGE_RANK_MAX_ITERATIONS: math.NaN()},
+	} {
+		if _, err := NewVertexProgram(
+			Query{QueryType: PAGE_RANK, Nodes: []uint64{1}, Params: params},
+		); err == nil {
+			t.Errorf("pagerank query with params %v is valid", params)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package bagel
+
+import (
+	"encoding/gob"
+	"errors"
+)
+
+// personalizedPageRankProgram computes PageRank with teleportation only to
+// the seed vertices of the query, so the value of a vertex is its relevance
+// to the seeds. Like PageRank, every seed gets the same teleport flow and the
+// values add up to the number of seeds. A TopK query ranks the vertices most
+// relevant to the seeds, leaving out the seeds themselves
+type personalizedPageRankProgram struct {
+	pageRankProgram
+}
+
+func init() {
+	RegisterVertexProgram(
+		PERSONALIZED_PAGE_RANK, newPersonalizedPageRankProgram,
+	)
+
+	// workers rank their own vertices, which are sent to the coord as an
+	// interface{}
+	gob.Register([]RankedVertex{})
+}
+
+func newPersonalizedPageRankProgram(query Query) VertexProgram {
+	seeds := make(map[uint64]bool, len(query.Nodes))
+	for _, vertexId := range query.Nodes {
+		seeds[vertexId] = true
+	}
+	return &personalizedPageRankProgram{
+		pageRankProgram{query: query, seeds: seeds},
+	}
+}
+
+func (p *personalizedPageRankProgram) Validate() error {
+	if err := p.validateParams(); err != nil {
+		return err
+	}
+	if len(p.query.Nodes) == 0 {
+		return errors.New("personalized pagerank needs at least one seed vertex")
+	}
+	if p.query.IsTargetQuery() {
+		return errors.New(
+			"personalized pagerank returns a ranking or the vertex values",
+		)
+	}
+	return nil
+}
+
+// PartialResult ranks the worker's vertices that are not seeds
+func (p *personalizedPageRankProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	if !p.query.IsTopKQuery() {
+		return nil
+	}
+
+	candidates := make([]RankedVertex, 0, len(vertices))
+	for _, vertex := range vertices {
+		if p.seeds[vertex.Id] {
+			continue
+		}
+		candidates = append(
+			candidates, RankedVertex{
+				VertexId: vertex.Id, Score: vertex.CurrentValue.(float64),
+			},
+		)
+	}
+	return topK(candidates, int(p.query.TopK))
+}
+
+func (p *personalizedPageRankProgram) MergeResults(
+	partials []interface{},
+) interface{} {
+	if !p.query.IsTopKQuery() {
+		return nil
+	}
+
+	rankings := make([][]RankedVertex, 0, len(partials))
+	for _, partial := range partials {
+		rankings = append(rankings, partial.([]RankedVertex))
+	}
+	return mergeTopK(rankings, int(p.query.TopK))
+}
@@ -0,0 +1,72 @@
+package bagel
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPersonalizedPageRankRanking(t *testing.T) {
+	// 5 and 6 cannot be reached from the seed, and the flow of the dangling
+	// vertex 4 goes back to the seed
+	graph := map[uint64][]uint64{
+		1: {2, 3},
+		2: {4},
+		3: {4},
+		4: {},
+		5: {6},
+		6: {5},
+	}
+	w, result := runTestQuery(
+		t, Query{
+			QueryType: PERSONALIZED_PAGE_RANK, Nodes: []uint64{1},
+			ResultMode: RESULT_TOP_K, TopK: 3,
+			Params: map[string]float64{PAGE_RANK_TOLERANCE: 1e-4},
+		}, graph,
+	)
+
+	// r1 = 0.15 + 0.85 r4, r2 = r3 = 0.85 r1 / 2 and r4 = 0.85 (r2 + r3).
+	// The seed ranks first but is left out of the ranking
+	expected := []RankedVertex{
+		{VertexId: 4, Score: 0.280855}, {VertexId: 2, Score: 0.165209},
+		{VertexId: 3, Score: 0.165209},
+	}
+	ranking := result.([]RankedVertex)
+	if len(ranking) != len(expected) {
+		t.Fatalf("expected ranking %v but got %v", expected, ranking)
+	}
+	for idx := range expected {
+		if ranking[idx].VertexId != expected[idx].VertexId ||
+			math.Abs(ranking[idx].Score-expected[idx].Score) > 1e-3 {
+			t.Errorf("expected ranking %v but got %v", expected, ranking)
+		}
+	}
+	for _, id := range []uint64{5, 6} {
+		if w.Vertices[id].CurrentValue != float64(0) {
+			t.Errorf(
+				"unreachable vertex %v has score %v", id,
+				w.Vertices[id].CurrentValue,
+			)
+		}
+	}
+}
+
+func TestPersonalizedPageRankValidation(t *testing.T) {
+	if _, err := NewVertexProgram(
+		Query{QueryType: PERSONALIZED_PAGE_RANK, ResultMode: RESULT_TOP_K},
+	); err == nil {
+		t.Errorf("personalized pagerank query without seeds is valid")
+	}
+	if _, err := NewVertexProgram(
+		Query{QueryType: PERSONALIZED_PAGE_RANK, Nodes: []uint64{1}},
+	); err == nil {
+		t.Errorf("personalized pagerank query for a target vertex is valid")
+	}
+	if _, err := NewVertexProgram(
+		Query{
+			QueryType: PERSONALIZED_PAGE_RANK, Nodes: []uint64{1, 2},
+			ResultMode: RESULT_VERTEX_VALUES,
+		},
+	); err != nil {
+		t.Errorf("valid personalized pagerank query returned error: %v", err)
+	}
+}
@@ -23,19 +23,67 @@ const (
 type QUERY_TYPE int32
 
 const (
-	QUERY_TYPE_PAGE_RANK     QUERY_TYPE = 0
-	QUERY_TYPE_SHORTEST_PATH QUERY_TYPE = 1
+	QUERY_TYPE_PAGE_RANK                     QUERY_TYPE = 0
+	QUERY_TYPE_SHORTEST_PATH                 QUERY_TYPE = 1
+	QUERY_TYPE_CONNECTED_COMPONENTS          QUERY_TYPE = 2
+	QUERY_TYPE_WEIGHTED_SHORTEST_PATH        QUERY_TYPE = 3
+	QUERY_TYPE_DEGREE                        QUERY_TYPE = 4
+	QUERY_TYPE_STRONGLY_CONNECTED_COMPONENTS QUERY_TYPE = 5
+	QUERY_TYPE_TRIANGLE_COUNT                QUERY_TYPE = 6
+	QUERY_TYPE_LABEL_PROPAGATION             QUERY_TYPE = 7
+	QUERY_TYPE_PERSONALIZED_PAGE_RANK        QUERY_TYPE = 8
+	QUERY_TYPE_HITS                          QUERY_TYPE = 9
+	QUERY_TYPE_K_CORE                        QUERY_TYPE = 10
+	QUERY_TYPE_K_HOP_NEIGHBORHOOD            QUERY_TYPE = 11
+	QUERY_TYPE_HYPER_ANF                     QUERY_TYPE = 12
+	QUERY_TYPE_GRAPH_COLORING                QUERY_TYPE = 13
+	QUERY_TYPE_MINIMUM_SPANNING_FOREST       QUERY_TYPE = 14
+	QUERY_TYPE_RANDOM_WALK                   QUERY_TYPE = 15
+	QUERY_TYPE_LINK_PREDICTION               QUERY_TYPE = 16
+	QUERY_TYPE_BETWEENNESS                   QUERY_TYPE = 17
 )
 
 // Enum value maps for QUERY_TYPE.
 var (
 	QUERY_TYPE_name = map[int32]string{
-		0: "PAGE_RANK",
-		1: "SHORTEST_PATH",
+		0:  "PAGE_RANK",
+		1:  "SHORTEST_PATH",
+		2:  "CONNECTED_COMPONENTS",
+		3:  "WEIGHTED_SHORTEST_PATH",
+		4:  "DEGREE",
+		5:  "STRONGLY_CONNECTED_COMPONENTS",
+		6:  "TRIANGLE_COUNT",
+		7:  "LABEL_PROPAGATION",
+		8:  "PERSONALIZED_PAGE_RANK",
+		9:  "HITS",
+		10: "K_CORE",
+		11: "K_HOP_NEIGHBORHOOD",
+		12: "HYPER_ANF",
+		13: "GRAPH_COLORING",
+		14: "MINIMUM_SPANNING_FOREST",
+		15: "RANDOM_WALK",
+		16: "LINK_PREDICTION",
+		17: "BETWEENNESS",
 	}
 	QUERY_TYPE_value = map[string]int32{
-		"PAGE_RANK":     0,
-		"SHORTEST_PATH": 1,
+		"PAGE_RANK":                     0,
+		"SHORTEST_PATH":                 1,
+		"CONNECTED_COMPONENTS":          2,
+		"WEIGHTED_SHORTEST_PATH":        3,
+		"DEGREE":                        4,
+		"STRONGLY_CONNECTED_COMPONENTS": 5,
+		"TRIANGLE_COUNT":                6,
+		"LABEL_PROPAGATION":             7,
+		"PERSONALIZED_PAGE_RANK":        8,
+		"HITS":                          9,
+		"K_CORE":                        10,
+		"K_HOP_NEIGHBORHOOD":            11,
+		"HYPER_ANF":                     12,
+		"GRAPH_COLORING":                13,
+		"MINIMUM_SPANNING_FOREST":       14,
+		"RANDOM_WALK":                   15,
+		"LINK_PREDICTION":               16,
+		"BETWEENNESS":                   17,
 	}
 )
 
@@ -66,6 +114,58 @@ func (QUERY_TYPE) EnumDescriptor() ([]byte, []int) {
 	return file_coord_proto_rawDescGZIP(), []int{0}
 }
 
+type RESULT_MODE int32
+
+const (
+	// value of the query's target vertex
+	RESULT_MODE_TARGET RESULT_MODE = 0
+	// final value of every vertex, or of the vertices in ResultNodes
+	RESULT_MODE_VERTEX_VALUES RESULT_MODE = 1
+	// the TopK vertices with the highest values
+	RESULT_MODE_TOP_K RESULT_MODE = 2
+)
+
+// Enum value maps for RESULT_MODE.
+var (
+	RESULT_MODE_name = map[int32]string{
+		0: "TARGET",
+		1: "VERTEX_VALUES",
+		2: "TOP_K",
+	}
+	RESULT_MODE_value = map[string]int32{
+		"TARGET":        0,
+		"VERTEX_VALUES": 1,
+		"TOP_K":         2,
+	}
+)
+
+func (x RESULT_MODE) Enum() *RESULT_MODE {
+	p := new(RESULT_MODE)
+	*p = x
+	return p
+}
+
+func (x RESULT_MODE) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RESULT_MODE) Descriptor() protoreflect.EnumDescriptor {
+	return file_coord_proto_enumTypes[1].Descriptor()
+}
+
+func (RESULT_MODE) Type() protoreflect.EnumType {
+	return &file_coord_proto_enumTypes[1]
+}
+
+func (x RESULT_MODE) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RESULT_MODE.Descriptor instead.
+func (RESULT_MODE) EnumDescriptor() ([]byte, []int) {
+	return file_coord_proto_rawDescGZIP(), []int{1}
+}
+
 type Query struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -76,6 +176,13 @@ type Query struct {
 	Nodes     []uint64   `protobuf:"varint,3,rep,packed,name=Nodes,proto3" json:"Nodes,omitempty"`
 	Graph     string     `protobuf:"bytes,4,opt,name=Graph,proto3" json:"Graph,omitempty"`
 	TableName string     `protobuf:"bytes,5,opt,name=TableName,proto3" json:"TableName,omitempty"`
+	// name of a registered vertex program, overrides QueryType when set
+	Algorithm   string      `protobuf:"bytes,6,opt,name=Algorithm,proto3" json:"Algorithm,omitempty"`
+	ResultMode  RESULT_MODE `protobuf:"varint,7,opt,name=ResultMode,proto3,enum=coord.RESULT_MODE" json:"ResultMode,omitempty"`
+	ResultNodes []uint64    `protobuf:"varint,8,rep,packed,name=ResultNodes,proto3" json:"ResultNodes,omitempty"`
+	TopK        uint32      `protobuf:"varint,9,opt,name=TopK,proto3" json:"TopK,omitempty"`
+	// algorithm parameters, such as MaxSuperSteps for label propagation
+	Params map[string]float64 `protobuf:"bytes,10,rep,name=Params,proto3" json:"Params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
 }
 
 func (x *Query) Reset() {
@@ -145,6 +252,41 @@ func (x *Query) GetTableName() string {
 	return ""
 }
 
+func (x *Query) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *Query) GetResultMode() RESULT_MODE {
+	if x != nil {
+		return x.ResultMode
+	}
+	return RESULT_MODE_TARGET
+}
+
+func (x *Query) GetResultNodes() []uint64 {
+	if x != nil {
+		return x.ResultNodes
+	}
+	return nil
+}
+
+func (x *Query) GetTopK() uint32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *Query) GetParams() map[string]float64 {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
 type QueryResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -153,6 +295,43 @@ type QueryResult struct {
 	Query  *Query  `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
 	Result float64 `protobuf:"fixed64,2,opt,name=Result,proto3" json:"Result,omitempty"`
 	Error  string  `protobuf:"bytes,3,opt,name=Error,proto3" json:"Error,omitempty"`
+	// vertices of the path from source to destination for shortest path queries
+	Path []uint64 `protobuf:"varint,4,rep,packed,name=Path,proto3" json:"Path,omitempty"`
+	// best ranked vertices first for TOP_K queries
+	Ranking []*RankedVertex `protobuf:"bytes,5,rep,name=Ranking,proto3" json:"Ranking,omitempty"`
+	// number of vertices in the strongly connected component or community of
+	// the queried vertex, whose id is the Result
+	ComponentSize uint64 `protobuf:"varint,6,opt,name=ComponentSize,proto3" json:"ComponentSize,omitempty"`
+	// number of strongly connected components or communities of each size when
+	// no vertex is queried, the Result is the number of components
+	SizeHistogram map[uint64]uint64 `protobuf:"bytes,7,rep,name=SizeHistogram,proto3" json:"SizeHistogram,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// hub score of the queried vertex for HITS queries, whose Result is the
+	// authority score
+	HubScore float64 `protobuf:"fixed64,8,opt,name=HubScore,proto3" json:"HubScore,omitempty"`
+	// best hubs first for HITS TOP_K queries, whose Ranking holds the best
+	// authorities
+	HubRanking []*RankedVertex `protobuf:"bytes,9,rep,name=HubRanking,proto3" json:"HubRanking,omitempty"`
+	// largest coreness of the graph and number of vertices in its k-core for
+	// K_CORE queries, whose Result is the coreness of the queried vertex
+	MaxCoreness uint64 `protobuf:"varint,10,opt,name=MaxCoreness,proto3" json:"MaxCoreness,omitempty"`
+	MaxCoreSize uint64 `protobuf:"varint,11,opt,name=MaxCoreSize,proto3" json:"MaxCoreSize,omitempty"`
+	// estimated number of pairs of vertices within each number of hops and
+	// average distance for HYPER_ANF queries, whose Result is the effective
+	// diameter
+	NeighborhoodFunction []float64 `protobuf:"fixed64,12,rep,packed,name=NeighborhoodFunction,proto3" json:"NeighborhoodFunction,omitempty"`
+	AverageDistance      float64   `protobuf:"fixed64,13,opt,name=AverageDistance,proto3" json:"AverageDistance,omitempty"`
+	// number of colors of the graph for GRAPH_COLORING queries, whose Result
+	// is the color of the queried vertex
+	NumColors uint64 `protobuf:"varint,14,opt,name=NumColors,proto3" json:"NumColors,omitempty"`
+	// number of trees of the forest for MINIMUM_SPANNING_FOREST queries, whose
+	// Result is the weight of the forest
+	NumTrees uint64 `protobuf:"varint,15,opt,name=NumTrees,proto3" json:"NumTrees,omitempty"`
+	// best candidates first with all their scores for LINK_PREDICTION
+	// queries, whose Ranking holds the scores of the query's Metric
+	LinkCandidates []*LinkCandidate `protobuf:"bytes,16,rep,name=LinkCandidates,proto3" json:"LinkCandidates,omitempty"`
+	// number of sampled sources the scores of BETWEENNESS queries are
+	// estimated from
+	NumSources uint64 `protobuf:"varint,17,opt,name=NumSources,proto3" json:"NumSources,omitempty"`
 }
 
 func (x *QueryResult) Reset() {
@@ -208,6 +387,414 @@ func (x *QueryResult) GetError() string {
 	return ""
 }
 
+func (x *QueryResult) GetPath() []uint64 {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *QueryResult) GetRanking() []*RankedVertex {
+	if x != nil {
+		return x.Ranking
+	}
+	return nil
+}
+
+func (x *QueryResult) GetComponentSize() uint64 {
+	if x != nil {
+		return x.ComponentSize
+	}
+	return 0
+}
+
+func (x *QueryResult) GetSizeHistogram() map[uint64]uint64 {
+	if x != nil {
+		return x.SizeHistogram
+	}
+	return nil
+}
+
+func (x *QueryResult) GetHubScore() float64 {
+	if x != nil {
+		return x.HubScore
+	}
+	return 0
+}
+
+func (x *QueryResult) GetHubRanking() []*RankedVertex {
+	if x != nil {
+		return x.HubRanking
+	}
+	return nil
+}
+
+func (x *QueryResult) GetMaxCoreness() uint64 {
+	if x != nil {
+		return x.MaxCoreness
+	}
+	return 0
+}
+
+func (x *QueryResult) GetMaxCoreSize() uint64 {
+	if x != nil {
+		return x.MaxCoreSize
+	}
+	return 0
+}
+
+func (x *QueryResult) GetNeighborhoodFunction() []float64 {
+	if x != nil {
+		return x.NeighborhoodFunction
+	}
+	return nil
+}
+
+func (x *QueryResult) GetAverageDistance() float64 {
+	if x != nil {
+		return x.AverageDistance
+	}
+	return 0
+}
+
+func (x *QueryResult) GetNumColors() uint64 {
+	if x != nil {
+		return x.NumColors
+	}
+	return 0
+}
+
+func (x *QueryResult) GetNumTrees() uint64 {
+	if x != nil {
+		return x.NumTrees
+	}
+	return 0
+}
+
+func (x *QueryResult) GetLinkCandidates() []*LinkCandidate {
+	if x != nil {
+		return x.LinkCandidates
+	}
+	return nil
+}
+
+func (x *QueryResult) GetNumSources() uint64 {
+	if x != nil {
+		return x.NumSources
+	}
+	return 0
+}
+
+type LinkCandidate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VertexId        uint64  `protobuf:"varint,1,opt,name=VertexId,proto3" json:"VertexId,omitempty"`
+	Jaccard         float64 `protobuf:"fixed64,2,opt,name=Jaccard,proto3" json:"Jaccard,omitempty"`
+	AdamicAdar      float64 `protobuf:"fixed64,3,opt,name=AdamicAdar,proto3" json:"AdamicAdar,omitempty"`
+	CommonNeighbors uint64  `protobuf:"varint,4,opt,name=CommonNeighbors,proto3" json:"CommonNeighbors,omitempty"`
+}
+
+func (x *LinkCandidate) Reset() {
+	*x = LinkCandidate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_coord_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkCandidate) ProtoMessage() {}
+
+func (x *LinkCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_coord_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkCandidate.ProtoReflect.Descriptor instead.
+func (*LinkCandidate) Descriptor() ([]byte, []int) {
+	return file_coord_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LinkCandidate) GetVertexId() uint64 {
+	if x != nil {
+		return x.VertexId
+	}
+	return 0
+}
+
+func (x *LinkCandidate) GetJaccard() float64 {
+	if x != nil {
+		return x.Jaccard
+	}
+	return 0
+}
+
+func (x *LinkCandidate) GetAdamicAdar() float64 {
+	if x != nil {
+		return x.AdamicAdar
+	}
+	return 0
+}
+
+func (x *LinkCandidate) GetCommonNeighbors() uint64 {
+	if x != nil {
+		return x.CommonNeighbors
+	}
+	return 0
+}
+
+type RankedVertex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VertexId uint64  `protobuf:"varint,1,opt,name=VertexId,proto3" json:"VertexId,omitempty"`
+	Score    float64 `protobuf:"fixed64,2,opt,name=Score,proto3" json:"Score,omitempty"`
+}
+
+func (x *RankedVertex) Reset() {
+	*x = RankedVertex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_coord_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RankedVertex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedVertex) ProtoMessage() {}
+
+func (x *RankedVertex) ProtoReflect() protoreflect.Message {
+	mi := &file_coord_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedVertex.ProtoReflect.Descriptor instead.
+func (*RankedVertex) Descriptor() ([]byte, []int) {
+	return file_coord_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RankedVertex) GetVertexId() uint64 {
+	if x != nil {
+		return x.VertexId
+	}
+	return 0
+}
+
+func (x *RankedVertex) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type VertexValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VertexId uint64  `protobuf:"varint,1,opt,name=VertexId,proto3" json:"VertexId,omitempty"`
+	Value    float64 `protobuf:"fixed64,2,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (x *VertexValue) Reset() {
+	*x = VertexValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_coord_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VertexValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VertexValue) ProtoMessage() {}
+
+func (x *VertexValue) ProtoReflect() protoreflect.Message {
+	mi := &file_coord_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VertexValue.ProtoReflect.Descriptor instead.
+func (*VertexValue) Descriptor() ([]byte, []int) {
+	return file_coord_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VertexValue) GetVertexId() uint64 {
+	if x != nil {
+		return x.VertexId
+	}
+	return 0
+}
+
+func (x *VertexValue) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// undirected weighted edge of a query result, such as an edge of a minimum
+// spanning forest
+type ResultEdge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source uint64  `protobuf:"varint,1,opt,name=Source,proto3" json:"Source,omitempty"`
+	Dest   uint64  `protobuf:"varint,2,opt,name=Dest,proto3" json:"Dest,omitempty"`
+	Weight float64 `protobuf:"fixed64,3,opt,name=Weight,proto3" json:"Weight,omitempty"`
+}
+
+func (x *ResultEdge) Reset() {
+	*x = ResultEdge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_coord_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultEdge) ProtoMessage() {}
+
+func (x *ResultEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_coord_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultEdge.ProtoReflect.Descriptor instead.
+func (*ResultEdge) Descriptor() ([]byte, []int) {
+	return file_coord_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResultEdge) GetSource() uint64 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+func (x *ResultEdge) GetDest() uint64 {
+	if x != nil {
+		return x.Dest
+	}
+	return 0
+}
+
+func (x *ResultEdge) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type VertexValuesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VertexValues []*VertexValue `protobuf:"bytes,1,rep,name=VertexValues,proto3" json:"VertexValues,omitempty"`
+	Error        string         `protobuf:"bytes,2,opt,name=Error,proto3" json:"Error,omitempty"`
+	// result edges of the queries returning edges rather than vertex values
+	Edges []*ResultEdge `protobuf:"bytes,3,rep,name=Edges,proto3" json:"Edges,omitempty"`
+}
+
+func (x *VertexValuesResponse) Reset() {
+	*x = VertexValuesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_coord_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VertexValuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VertexValuesResponse) ProtoMessage() {}
+
+func (x *VertexValuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coord_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VertexValuesResponse.ProtoReflect.Descriptor instead.
+func (*VertexValuesResponse) Descriptor() ([]byte, []int) {
+	return file_coord_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VertexValuesResponse) GetVertexValues() []*VertexValue {
+	if x != nil {
+		return x.VertexValues
+	}
+	return nil
+}
+
+func (x *VertexValuesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *VertexValuesResponse) GetEdges() []*ResultEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
 type VertexMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -221,7 +808,7 @@ type VertexMessage struct {
 func (x *VertexMessage) Reset() {
 	*x = VertexMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_coord_proto_msgTypes[2]
+		mi := &file_coord_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -234,7 +821,7 @@ func (x *VertexMessage) String() string {
 func (*VertexMessage) ProtoMessage() {}
 
 func (x *VertexMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_coord_proto_msgTypes[2]
+	mi := &file_coord_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +834,7 @@ func (x *VertexMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VertexMessage.ProtoReflect.Descriptor instead.
 func (*VertexMessage) Descriptor() ([]byte, []int) {
-	return file_coord_proto_rawDescGZIP(), []int{2}
+	return file_coord_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *VertexMessage) GetSourceVertexId() uint64 {
@@ -282,7 +869,7 @@ type VertexMessages struct {
 func (x *VertexMessages) Reset() {
 	*x = VertexMessages{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_coord_proto_msgTypes[3]
+		mi := &file_coord_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -295,7 +882,7 @@ func (x *VertexMessages) String() string {
 func (*VertexMessages) ProtoMessage() {}
 
 func (x *VertexMessages) ProtoReflect() protoreflect.Message {
-	mi := &file_coord_proto_msgTypes[3]
+	mi := &file_coord_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -308,7 +895,7 @@ func (x *VertexMessages) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VertexMessages.ProtoReflect.Descriptor instead.
 func (*VertexMessages) Descriptor() ([]byte, []int) {
-	return file_coord_proto_rawDescGZIP(), []int{3}
+	return file_coord_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *VertexMessages) GetVertexMessages() []*VertexMessage {
@@ -327,7 +914,7 @@ type QueryProgressRequest struct {
 func (x *QueryProgressRequest) Reset() {
 	*x = QueryProgressRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_coord_proto_msgTypes[4]
+		mi := &file_coord_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -340,7 +927,7 @@ func (x *QueryProgressRequest) String() string {
 func (*QueryProgressRequest) ProtoMessage() {}
 
 func (x *QueryProgressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coord_proto_msgTypes[4]
+	mi := &file_coord_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -353,7 +940,7 @@ func (x *QueryProgressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryProgressRequest.ProtoReflect.Descriptor instead.
 func (*QueryProgressRequest) Descriptor() ([]byte, []int) {
-	return file_coord_proto_rawDescGZIP(), []int{4}
+	return file_coord_proto_rawDescGZIP(), []int{9}
 }
 
 type QueryProgressResponse struct {
@@ -368,7 +955,7 @@ type QueryProgressResponse struct {
 func (x *QueryProgressResponse) Reset() {
 	*x = QueryProgressResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_coord_proto_msgTypes[5]
+		mi := &file_coord_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -381,7 +968,7 @@ func (x *QueryProgressResponse) String() string {
 func (*QueryProgressResponse) ProtoMessage() {}
 
 func (x *QueryProgressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coord_proto_msgTypes[5]
+	mi := &file_coord_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -394,7 +981,7 @@ func (x *QueryProgressResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryProgressResponse.ProtoReflect.Descriptor instead.
 func (*QueryProgressResponse) Descriptor() ([]byte, []int) {
-	return file_coord_proto_rawDescGZIP(), []int{5}
+	return file_coord_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *QueryProgressResponse) GetSuperstepNumber() uint64 {
@@ -422,7 +1009,7 @@ type WorkerVertices struct {
 func (x *WorkerVertices) Reset() {
 	*x = WorkerVertices{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_coord_proto_msgTypes[6]
+		mi := &file_coord_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -435,7 +1022,7 @@ func (x *WorkerVertices) String() string {
 func (*WorkerVertices) ProtoMessage() {}
 
 func (x *WorkerVertices) ProtoReflect() protoreflect.Message {
-	mi := &file_coord_proto_msgTypes[6]
+	mi := &file_coord_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -448,7 +1035,7 @@ func (x *WorkerVertices) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WorkerVertices.ProtoReflect.Descriptor instead.
 func (*WorkerVertices) Descriptor() ([]byte, []int) {
-	return file_coord_proto_rawDescGZIP(), []int{6}
+	return file_coord_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *WorkerVertices) GetVertices() []uint64 {
@@ -467,7 +1054,7 @@ type FetchGraphRequest struct {
 func (x *FetchGraphRequest) Reset() {
 	*x = FetchGraphRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_coord_proto_msgTypes[7]
+		mi := &file_coord_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -480,7 +1067,7 @@ func (x *FetchGraphRequest) String() string {
 func (*FetchGraphRequest) ProtoMessage() {}
 
 func (x *FetchGraphRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coord_proto_msgTypes[7]
+	mi := &file_coord_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -493,7 +1080,7 @@ func (x *FetchGraphRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchGraphRequest.ProtoReflect.Descriptor instead.
 func (*FetchGraphRequest) Descriptor() ([]byte, []int) {
-	return file_coord_proto_rawDescGZIP(), []int{7}
+	return file_coord_proto_rawDescGZIP(), []int{12}
 }
 
 type FetchGraphResponse struct {
@@ -507,7 +1094,7 @@ type FetchGraphResponse struct {
 func (x *FetchGraphResponse) Reset() {
 	*x = FetchGraphResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_coord_proto_msgTypes[8]
+		mi := &file_coord_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -520,7 +1107,7 @@ func (x *FetchGraphResponse) String() string {
 func (*FetchGraphResponse) ProtoMessage() {}
 
 func (x *FetchGraphResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coord_proto_msgTypes[8]
+	mi := &file_coord_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,7 +1120,7 @@ func (x *FetchGraphResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchGraphResponse.ProtoReflect.Descriptor instead.
 func (*FetchGraphResponse) Descriptor() ([]byte, []int) {
-	return file_coord_proto_rawDescGZIP(), []int{8}
+	return file_coord_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *FetchGraphResponse) GetWorkerVertices() map[uint32]*WorkerVertices {
@@ -547,7 +1134,7 @@ var File_coord_proto protoreflect.FileDescriptor
 
 var file_coord_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x63,
-	0x6f, 0x6f, 0x72, 0x64, 0x22, 0x9e, 0x01, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1a,
+	0x6f, 0x6f, 0x72, 0x64, 0x22, 0x93, 0x03, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1a,
 	0x0a, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x09, 0x51, 0x75,
 	0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e,
@@ -557,13 +1144,100 @@ var file_coord_proto_rawDesc = []byte{
 	0x73, 0x12, 0x14, 0x0a, 0x05, 0x47, 0x72, 0x61, 0x70, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x05, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x61, 0x62, 0x6c, 0x65,
 	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x54, 0x61, 0x62, 0x6c,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x5f, 0x0a, 0x0b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x71, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x12, 0x32, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x6f, 0x64,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e,
+	0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x52, 0x0a, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0b, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x6f, 0x70,
+	0x4b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x54, 0x6f, 0x70, 0x4b, 0x12, 0x30, 0x0a,
+	0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a,
+	0x39, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe2, 0x05, 0x0a, 0x0b, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x6f, 0x6f, 0x72,
+	0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x2d, 0x0a, 0x07, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x65, 0x64,
+	0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x52, 0x07, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x12,
+	0x24, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x7a, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x4b, 0x0a, 0x0d, 0x53, 0x69, 0x7a, 0x65, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63,
+	0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x2e, 0x53, 0x69, 0x7a, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0d, 0x53, 0x69, 0x7a, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72,
+	0x61, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x75, 0x62, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x48, 0x75, 0x62, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x33,
+	0x0a, 0x0a, 0x48, 0x75, 0x62, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x65,
+	0x64, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x52, 0x0a, 0x48, 0x75, 0x62, 0x52, 0x61, 0x6e, 0x6b,
+	0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x78, 0x43, 0x6f, 0x72, 0x65, 0x6e, 0x65,
+	0x73, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x4d, 0x61, 0x78, 0x43, 0x6f, 0x72,
+	0x65, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x78, 0x43, 0x6f, 0x72, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x4d, 0x61, 0x78, 0x43,
+	0x6f, 0x72, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x4e, 0x65, 0x69, 0x67, 0x68,
+	0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x0c, 0x20, 0x03, 0x28, 0x01, 0x52, 0x14, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68,
+	0x6f, 0x6f, 0x64, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x41,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x44, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x75, 0x6d, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x4e, 0x75, 0x6d, 0x43, 0x6f, 0x6c,
+	0x6f, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x4e, 0x75, 0x6d, 0x54, 0x72, 0x65, 0x65, 0x73, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4e, 0x75, 0x6d, 0x54, 0x72, 0x65, 0x65, 0x73, 0x12,
+	0x3c, 0x0a, 0x0e, 0x4c, 0x69, 0x6e, 0x6b, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0e, 0x4c,
+	0x69, 0x6e, 0x6b, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x4e, 0x75, 0x6d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x4e, 0x75, 0x6d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x1a, 0x40, 0x0a,
+	0x12, 0x53, 0x69, 0x7a, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x8f, 0x01, 0x0a, 0x0d, 0x4c, 0x69, 0x6e, 0x6b, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x4a, 0x61, 0x63, 0x63, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07,
+	0x4a, 0x61, 0x63, 0x63, 0x61, 0x72, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x41, 0x64, 0x61, 0x6d, 0x69,
+	0x63, 0x41, 0x64, 0x61, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x41, 0x64, 0x61,
+	0x6d, 0x69, 0x63, 0x41, 0x64, 0x61, 0x72, 0x12, 0x28, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72,
+	0x73, 0x22, 0x40, 0x0a, 0x0c, 0x52, 0x61, 0x6e, 0x6b, 0x65, 0x64, 0x56, 0x65, 0x72, 0x74, 0x65,
+	0x78, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x22, 0x3f, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x50, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x45, 0x64,
+	0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x65,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x44, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
+	0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x8d, 0x01, 0x0a, 0x14, 0x56, 0x65, 0x72, 0x74, 0x65,
+	0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x36, 0x0a, 0x0c, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x56, 0x65,
+	0x72, 0x74, 0x65, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0c, 0x56, 0x65, 0x72, 0x74, 0x65,
+	0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x27, 0x0a,
+	0x05, 0x45, 0x64, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63,
+	0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x45, 0x64, 0x67, 0x65, 0x52,
+	0x05, 0x45, 0x64, 0x67, 0x65, 0x73, 0x22, 0x71, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78,
 	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63,
 	0x65, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
 	0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x49, 0x64, 0x12,
@@ -607,14 +1281,43 @@ var file_coord_proto_rawDesc = []byte{
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76,
 	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6f,
 	0x72, 0x64, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65,
-	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x2e, 0x0a, 0x0a,
-	0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x41,
-	0x47, 0x45, 0x5f, 0x52, 0x41, 0x4e, 0x4b, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x48, 0x4f,
-	0x52, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x50, 0x41, 0x54, 0x48, 0x10, 0x01, 0x32, 0xce, 0x01, 0x0a,
-	0x05, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x12, 0x30, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x72, 0x74, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x12, 0x0c, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x1a, 0x12, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x51, 0x75, 0x65, 0x72,
+	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0xff, 0x02, 0x0a,
+	0x0a, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x12, 0x0d, 0x0a, 0x09, 0x50,
+	0x41, 0x47, 0x45, 0x5f, 0x52, 0x41, 0x4e, 0x4b, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x48,
+	0x4f, 0x52, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x50, 0x41, 0x54, 0x48, 0x10, 0x01, 0x12, 0x18, 0x0a,
+	0x14, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x45, 0x44, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4f,
+	0x4e, 0x45, 0x4e, 0x54, 0x53, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x57, 0x45, 0x49, 0x47, 0x48,
+	0x54, 0x45, 0x44, 0x5f, 0x53, 0x48, 0x4f, 0x52, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x50, 0x41, 0x54,
+	0x48, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x47, 0x52, 0x45, 0x45, 0x10, 0x04, 0x12,
+	0x21, 0x0a, 0x1d, 0x53, 0x54, 0x52, 0x4f, 0x4e, 0x47, 0x4c, 0x59, 0x5f, 0x43, 0x4f, 0x4e, 0x4e,
+	0x45, 0x43, 0x54, 0x45, 0x44, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x53,
+	0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x52, 0x49, 0x41, 0x4e, 0x47, 0x4c, 0x45, 0x5f, 0x43,
+	0x4f, 0x55, 0x4e, 0x54, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x5f,
+	0x50, 0x52, 0x4f, 0x50, 0x41, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x07, 0x12, 0x1a, 0x0a,
+	0x16, 0x50, 0x45, 0x52, 0x53, 0x4f, 0x4e, 0x41, 0x4c, 0x49, 0x5a, 0x45, 0x44, 0x5f, 0x50, 0x41,
+	0x47, 0x45, 0x5f, 0x52, 0x41, 0x4e, 0x4b, 0x10, 0x08, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x54,
+	0x53, 0x10, 0x09, 0x12, 0x0a, 0x0a, 0x06, 0x4b, 0x5f, 0x43, 0x4f, 0x52, 0x45, 0x10, 0x0a, 0x12,
+	0x16, 0x0a, 0x12, 0x4b, 0x5f, 0x48, 0x4f, 0x50, 0x5f, 0x4e, 0x45, 0x49, 0x47, 0x48, 0x42, 0x4f,
+	0x52, 0x48, 0x4f, 0x4f, 0x44, 0x10, 0x0b, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x59, 0x50, 0x45, 0x52,
+	0x5f, 0x41, 0x4e, 0x46, 0x10, 0x0c, 0x12, 0x12, 0x0a, 0x0e, 0x47, 0x52, 0x41, 0x50, 0x48, 0x5f,
+	0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x0d, 0x12, 0x1b, 0x0a, 0x17, 0x4d, 0x49,
+	0x4e, 0x49, 0x4d, 0x55, 0x4d, 0x5f, 0x53, 0x50, 0x41, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x5f, 0x46,
+	0x4f, 0x52, 0x45, 0x53, 0x54, 0x10, 0x0e, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x41, 0x4e, 0x44, 0x4f,
+	0x4d, 0x5f, 0x57, 0x41, 0x4c, 0x4b, 0x10, 0x0f, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x49, 0x4e, 0x4b,
+	0x5f, 0x50, 0x52, 0x45, 0x44, 0x49, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x10, 0x12, 0x0f, 0x0a,
+	0x0b, 0x42, 0x45, 0x54, 0x57, 0x45, 0x45, 0x4e, 0x4e, 0x45, 0x53, 0x53, 0x10, 0x11, 0x2a, 0x37,
+	0x0a, 0x0b, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x12, 0x0a, 0x0a,
+	0x06, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x56, 0x45, 0x52,
+	0x54, 0x45, 0x58, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x53, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05,
+	0x54, 0x4f, 0x50, 0x5f, 0x4b, 0x10, 0x02, 0x32, 0x93, 0x02, 0x0a, 0x05, 0x43, 0x6f, 0x6f, 0x72,
+	0x64, 0x12, 0x30, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x72, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x0c, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x12, 0x2e,
+	0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x65, 0x72,
+	0x74, 0x65, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x0c, 0x2e, 0x63, 0x6f, 0x6f, 0x72,
+	0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e,
+	0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x0d, 0x51, 0x75, 0x65, 0x72,
 	0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6f, 0x72,
 	0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x2e, 0x51,
@@ -640,41 +1343,59 @@ func file_coord_proto_rawDescGZIP() []byte {
 	return file_coord_proto_rawDescData
 }
 
-var file_coord_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_coord_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_coord_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_coord_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_coord_proto_goTypes = []interface{}{
 	(QUERY_TYPE)(0),               // 0: coord.QUERY_TYPE
-	(*Query)(nil),                 // 1: coord.Query
-	(*QueryResult)(nil),           // 2: coord.QueryResult
-	(*VertexMessage)(nil),         // 3: coord.VertexMessage
-	(*VertexMessages)(nil),        // 4: coord.VertexMessages
-	(*QueryProgressRequest)(nil),  // 5: coord.QueryProgressRequest
-	(*QueryProgressResponse)(nil), // 6: coord.QueryProgressResponse
-	(*WorkerVertices)(nil),        // 7: coord.WorkerVertices
-	(*FetchGraphRequest)(nil),     // 8: coord.FetchGraphRequest
-	(*FetchGraphResponse)(nil),    // 9: coord.FetchGraphResponse
-	nil,                           // 10: coord.QueryProgressResponse.MessagesEntry
-	nil,                           // 11: coord.FetchGraphResponse.WorkerVerticesEntry
+	(RESULT_MODE)(0),              // 1: coord.RESULT_MODE
+	(*Query)(nil),                 // 2: coord.Query
+	(*QueryResult)(nil),           // 3: coord.QueryResult
+	(*LinkCandidate)(nil),         // 4: coord.LinkCandidate
+	(*RankedVertex)(nil),          // 5: coord.RankedVertex
+	(*VertexValue)(nil),           // 6: coord.VertexValue
+	(*ResultEdge)(nil),            // 7: coord.ResultEdge
+	(*VertexValuesResponse)(nil),  // 8: coord.VertexValuesResponse
+	(*VertexMessage)(nil),         // 9: coord.VertexMessage
+	(*VertexMessages)(nil),        // 10: coord.VertexMessages
+	(*QueryProgressRequest)(nil),  // 11: coord.QueryProgressRequest
+	(*QueryProgressResponse)(nil), // 12: coord.QueryProgressResponse
+	(*WorkerVertices)(nil),        // 13: coord.WorkerVertices
+	(*FetchGraphRequest)(nil),     // 14: coord.FetchGraphRequest
+	(*FetchGraphResponse)(nil),    // 15: coord.FetchGraphResponse
+	nil,                           // 16: coord.Query.ParamsEntry
+	nil,                           // 17: coord.QueryResult.SizeHistogramEntry
+	nil,                           // 18: coord.QueryProgressResponse.MessagesEntry
+	nil,                           // 19: coord.FetchGraphResponse.WorkerVerticesEntry
 }
 var file_coord_proto_depIdxs = []int32{
 	0,  // 0: coord.Query.QueryType:type_name -> coord.QUERY_TYPE
-	1,  // 1: coord.QueryResult.Query:type_name -> coord.Query
-	3,  // 2: coord.VertexMessages.vertexMessages:type_name -> coord.VertexMessage
-	10, // 3: coord.QueryProgressResponse.messages:type_name -> coord.QueryProgressResponse.MessagesEntry
-	11, // 4: coord.FetchGraphResponse.workerVertices:type_name -> coord.FetchGraphResponse.WorkerVerticesEntry
-	4,  // 5: coord.QueryProgressResponse.MessagesEntry.value:type_name -> coord.VertexMessages
-	7,  // 6: coord.FetchGraphResponse.WorkerVerticesEntry.value:type_name -> coord.WorkerVertices
-	1,  // 7: coord.Coord.StartQuery:input_type -> coord.Query
-	5,  // 8: coord.Coord.QueryProgress:input_type -> coord.QueryProgressRequest
-	8,  // 9: coord.Coord.FetchGraph:input_type -> coord.FetchGraphRequest
-	2,  // 10: coord.Coord.StartQuery:output_type -> coord.QueryResult
-	6,  // 11: coord.Coord.QueryProgress:output_type -> coord.QueryProgressResponse
-	9,  // 12: coord.Coord.FetchGraph:output_type -> coord.FetchGraphResponse
-	10, // [10:13] is the sub-list for method output_type
-	7,  // [7:10] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	1,  // 1: coord.Query.ResultMode:type_name -> coord.RESULT_MODE
+	16, // 2: coord.Query.Params:type_name -> coord.Query.ParamsEntry
+	2,  // 3: coord.QueryResult.Query:type_name -> coord.Query
+	5,  // 4: coord.QueryResult.Ranking:type_name -> coord.RankedVertex
+	17, // 5: coord.QueryResult.SizeHistogram:type_name -> coord.QueryResult.SizeHistogramEntry
+	5,  // 6: coord.QueryResult.HubRanking:type_name -> coord.RankedVertex
+	4,  // 7: coord.QueryResult.LinkCandidates:type_name -> coord.LinkCandidate
+	6,  // 8: coord.VertexValuesResponse.VertexValues:type_name -> coord.VertexValue
+	7,  // 9: coord.VertexValuesResponse.Edges:type_name -> coord.ResultEdge
+	9,  // 10: coord.VertexMessages.vertexMessages:type_name -> coord.VertexMessage
+	18, // 11: coord.QueryProgressResponse.messages:type_name -> coord.QueryProgressResponse.MessagesEntry
+	19, // 12: coord.FetchGraphResponse.workerVertices:type_name -> coord.FetchGraphResponse.WorkerVerticesEntry
+	10, // 13: coord.QueryProgressResponse.MessagesEntry.value:type_name -> coord.VertexMessages
+	13, // 14: coord.FetchGraphResponse.WorkerVerticesEntry.value:type_name -> coord.WorkerVertices
+	2,  // 15: coord.Coord.StartQuery:input_type -> coord.Query
+	2,  // 16: coord.Coord.StreamVertexValues:input_type -> coord.Query
+	11, // 17: coord.Coord.QueryProgress:input_type -> coord.QueryProgressRequest
+	14, // 18: coord.Coord.FetchGraph:input_type -> coord.FetchGraphRequest
+	3,  // 19: coord.Coord.StartQuery:output_type -> coord.QueryResult
+	8,  // 20: coord.Coord.StreamVertexValues:output_type -> coord.VertexValuesResponse
+	12, // 21: coord.Coord.QueryProgress:output_type -> coord.QueryProgressResponse
+	15, // 22: coord.Coord.FetchGraph:output_type -> coord.FetchGraphResponse
+	19, // [19:23] is the sub-list for method output_type
+	15, // [15:19] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_coord_proto_init() }
@@ -708,7 +1429,7 @@ func file_coord_proto_init() {
 			}
 		}
 		file_coord_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VertexMessage); i {
+			switch v := v.(*LinkCandidate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -720,7 +1441,7 @@ func file_coord_proto_init() {
 			}
 		}
 		file_coord_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VertexMessages); i {
+			switch v := v.(*RankedVertex); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -732,7 +1453,7 @@ func file_coord_proto_init() {
 			}
 		}
 		file_coord_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryProgressRequest); i {
+			switch v := v.(*VertexValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -744,7 +1465,7 @@ func file_coord_proto_init() {
 			}
 		}
 		file_coord_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryProgressResponse); i {
+			switch v := v.(*ResultEdge); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -756,7 +1477,7 @@ func file_coord_proto_init() {
 			}
 		}
 		file_coord_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WorkerVertices); i {
+			switch v := v.(*VertexValuesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -768,7 +1489,7 @@ func file_coord_proto_init() {
 			}
 		}
 		file_coord_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchGraphRequest); i {
+			switch v := v.(*VertexMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -780,6 +1501,66 @@ func file_coord_proto_init() {
 			}
 		}
 		file_coord_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VertexMessages); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_coord_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryProgressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_coord_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryProgressResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_coord_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkerVertices); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_coord_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchGraphRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_coord_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FetchGraphResponse); i {
 			case 0:
 				return &v.state
@@ -797,8 +1578,8 @@ func file_coord_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_coord_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   11,
+			NumEnums:      2,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CoordClient interface {
 	StartQuery(ctx context.Context, in *Query, opts ...grpc.CallOption) (*QueryResult, error)
+	StreamVertexValues(ctx context.Context, in *Query, opts ...grpc.CallOption) (Coord_StreamVertexValuesClient, error)
 	QueryProgress(ctx context.Context, in *QueryProgressRequest, opts ...grpc.CallOption) (Coord_QueryProgressClient, error)
 	FetchGraph(ctx context.Context, in *FetchGraphRequest, opts ...grpc.CallOption) (*FetchGraphResponse, error)
 }
@@ -44,8 +45,40 @@ func (c *coordClient) StartQuery(ctx context.Context, in *Query, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *coordClient) StreamVertexValues(ctx context.Context, in *Query, opts ...grpc.CallOption) (Coord_StreamVertexValuesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Coord_ServiceDesc.Streams[0], "/coord.Coord/StreamVertexValues", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &coordStreamVertexValuesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Coord_StreamVertexValuesClient interface {
+	Recv() (*VertexValuesResponse, error)
+	grpc.ClientStream
+}
+
+type coordStreamVertexValuesClient struct {
+	grpc.ClientStream
+}
+
+func (x *coordStreamVertexValuesClient) Recv() (*VertexValuesResponse, error) {
+	m := new(VertexValuesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *coordClient) QueryProgress(ctx context.Context, in *QueryProgressRequest, opts ...grpc.CallOption) (Coord_QueryProgressClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Coord_ServiceDesc.Streams[0], "/coord.Coord/QueryProgress", opts...)
+	stream, err := c.cc.NewStream(ctx, &Coord_ServiceDesc.Streams[1], "/coord.Coord/QueryProgress", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +123,7 @@ func (c *coordClient) FetchGraph(ctx context.Context, in *FetchGraphRequest, opt
 // for forward compatibility
 type CoordServer interface {
 	StartQuery(context.Context, *Query) (*QueryResult, error)
+	StreamVertexValues(*Query, Coord_StreamVertexValuesServer) error
 	QueryProgress(*QueryProgressRequest, Coord_QueryProgressServer) error
 	FetchGraph(context.Context, *FetchGraphRequest) (*FetchGraphResponse, error)
 	mustEmbedUnimplementedCoordServer()
@@ -102,6 +136,9 @@ type UnimplementedCoordServer struct {
 func (UnimplementedCoordServer) StartQuery(context.Context, *Query) (*QueryResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartQuery not implemented")
 }
+func (UnimplementedCoordServer) StreamVertexValues(*Query, Coord_StreamVertexValuesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamVertexValues not implemented")
+}
 func (UnimplementedCoordServer) QueryProgress(*QueryProgressRequest, Coord_QueryProgressServer) error {
 	return status.Errorf(codes.Unimplemented, "method QueryProgress not implemented")
 }
@@ -139,6 +176,27 @@ func _Coord_StartQuery_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Coord_StreamVertexValues_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Query)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoordServer).StreamVertexValues(m, &coordStreamVertexValuesServer{stream})
+}
+
+type Coord_StreamVertexValuesServer interface {
+	Send(*VertexValuesResponse) error
+	grpc.ServerStream
+}
+
+type coordStreamVertexValuesServer struct {
+	grpc.ServerStream
+}
+
+func (x *coordStreamVertexValuesServer) Send(m *VertexValuesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Coord_QueryProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(QueryProgressRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -195,6 +253,11 @@ var Coord_ServiceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamVertexValues",
+			Handler:       _Coord_StreamVertexValues_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "QueryProgress",
 			Handler:       _Coord_QueryProgress_Handler,
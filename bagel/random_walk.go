@@ -0,0 +1,286 @@
+package bagel
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// query parameters of random walk queries
+const (
+	// RANDOM_WALK_NUM_WALKS is the number of walks starting at every vertex
+	RANDOM_WALK_NUM_WALKS = "NumWalks"
+	// RANDOM_WALK_LENGTH is the number of vertices of a walk, including the
+	// vertex it starts at. Walks reaching a vertex without out-edges end
+	// there
+	RANDOM_WALK_LENGTH = "WalkLength"
+	// RANDOM_WALK_RETURN is the node2vec return parameter p: a walk goes
+	// back to the vertex it came from with a weight divided by p
+	RANDOM_WALK_RETURN = "P"
+	// RANDOM_WALK_IN_OUT is the node2vec in-out parameter q: a walk moves
+	// away from the vertex it came from with a weight divided by q
+	RANDOM_WALK_IN_OUT = "Q"
+	// RANDOM_WALK_SEED picks the walks of a query
+	RANDOM_WALK_SEED = "Seed"
+)
+
+// default parameters of a random walk query, whose walks are uniform
+const (
+	defaultRandomWalkNumWalks = 10
+	defaultRandomWalkLength   = 80
+	defaultRandomWalkBias     = 1
+)
+
+// RandomWalk is a walker, the message a walk moves along the edges with
+type RandomWalk struct {
+	// the walk is the Index-th one starting at Path[0]
+	Index int
+	Path  []uint64
+	// out-neighbors of the vertex the walk comes from, which biased walks
+	// need to tell how far the next vertices are from it
+	PreviousNeighbors []uint64
+}
+
+// randomWalkProgram generates random walks along the out-edges of the graph,
+// for embedding training. Every vertex starts NumWalks walks, and each walk
+// moves one edge per superstep, picking the next vertex with a probability
+// proportional to the weight of the edge. With P or Q set, walks are biased
+// like node2vec by the distance of the next vertex to the previous one.
+// Finished walks are kept by the vertex they end at, and written to a local
+// file by every worker once the query ends
+type randomWalkProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(RANDOM_WALK, newRandomWalkProgram)
+
+	// walks are sent and checkpointed as an interface{}
+	gob.Register(RandomWalk{})
+	gob.Register([][]uint64{})
+}
+
+func newRandomWalkProgram(query Query) VertexProgram {
+	return &randomWalkProgram{query: query}
+}
+
+func (p *randomWalkProgram) Validate() error {
+	if !p.query.IsTargetQuery() || len(p.query.Nodes) > 0 {
+		return errors.New("random walks are written by the workers")
+	}
+	if !p.query.IsIntParam(
+		RANDOM_WALK_NUM_WALKS, defaultRandomWalkNumWalks, 1, math.MaxInt32,
+	) || !p.query.IsIntParam(
+		RANDOM_WALK_LENGTH, defaultRandomWalkLength, 1, math.MaxInt32,
+	) {
+		return errors.New("random walks need a whole number of walks and steps")
+	}
+	if !isPositive(p.returnParam()) || !isPositive(p.inOutParam()) {
+		return errors.New("random walk p and q must be positive")
+	}
+	// seeds past 2^53 are not whole floats apart, so they are not accepted
+	if !p.query.IsIntParam(RANDOM_WALK_SEED, 0, -1<<53, 1<<53) {
+		return errors.New("random walk seed must be a whole number")
+	}
+	return nil
+}
+
+// isPositive reports whether value is a positive, finite number
+func isPositive(value float64) bool {
+	return value > 0 && !math.IsInf(value, 1)
+}
+
+func (p *randomWalkProgram) numWalks() int {
+	return int(
+		p.query.Param(RANDOM_WALK_NUM_WALKS, defaultRandomWalkNumWalks),
+	)
+}
+
+func (p *randomWalkProgram) walkLength() int {
+	return int(p.query.Param(RANDOM_WALK_LENGTH, defaultRandomWalkLength))
+}
+
+func (p *randomWalkProgram) returnParam() float64 {
+	return p.query.Param(RANDOM_WALK_RETURN, defaultRandomWalkBias)
+}
+
+func (p *randomWalkProgram) inOutParam() float64 {
+	return p.query.Param(RANDOM_WALK_IN_OUT, defaultRandomWalkBias)
+}
+
+func (p *randomWalkProgram) isBiased() bool {
+	return p.returnParam() != 1 || p.inOutParam() != 1
+}
+
+// InitialValue is the walks ending at the vertex
+func (p *randomWalkProgram) InitialValue(v *Vertex) interface{} {
+	return [][]uint64{}
+}
+
+func (p *randomWalkProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	walks := make([]RandomWalk, 0, len(v.Messages))
+	if v.SuperStepNum() <= 1 {
+		for idx := 0; idx < p.numWalks(); idx++ {
+			walks = append(walks, RandomWalk{Index: idx, Path: []uint64{v.Id}})
+		}
+	}
+	for _, message := range v.Messages {
+		walks = append(walks, message.Value.(RandomWalk))
+	}
+
+	var result []Message
+	for _, walk := range walks {
+		if len(walk.Path) >= p.walkLength() || len(v.Neighbors) == 0 {
+			v.CurrentValue = append(v.CurrentValue.([][]uint64), walk.Path)
+			continue
+		}
+
+		next := p.nextVertex(v, walk)
+		path := make([]uint64, len(walk.Path)+1)
+		copy(path, walk.Path)
+		path[len(walk.Path)] = next
+		step := RandomWalk{Index: walk.Index, Path: path}
+		if p.isBiased() {
+			step.PreviousNeighbors = v.Neighbors
+		}
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id, DestVertexId: next, Value: step,
+			},
+		)
+	}
+	return result
+}
+
+// nextVertex picks the out-neighbor the walk moves to. The weight of the edge
+// to a neighbor is divided by P if the neighbor is the previous vertex of
+// the walk, and by Q if it is not one of the previous vertex's out-neighbors
+func (p *randomWalkProgram) nextVertex(v *Vertex, walk RandomWalk) uint64 {
+	hasPrevious := p.isBiased() && len(walk.Path) >= 2
+	var previous uint64
+	isPreviousNeighbor := make(map[uint64]bool, len(walk.PreviousNeighbors))
+	if hasPrevious {
+		previous = walk.Path[len(walk.Path)-2]
+		for _, neighborVertexId := range walk.PreviousNeighbors {
+			isPreviousNeighbor[neighborVertexId] = true
+		}
+	}
+
+	weights := make([]float64, len(v.Neighbors))
+	total := 0.0
+	for idx, neighborVertexId := range v.Neighbors {
+		weights[idx] = v.EdgeWeight(idx)
+		if hasPrevious && neighborVertexId == previous {
+			weights[idx] /= p.returnParam()
+		} else if hasPrevious && !isPreviousNeighbor[neighborVertexId] {
+			weights[idx] /= p.inOutParam()
+		}
+		total += weights[idx]
+	}
+
+	random := p.random(walk)
+	if total <= 0 {
+		return v.Neighbors[int(random*float64(len(v.Neighbors)))]
+	}
+	remaining := random * total
+	for idx, neighborVertexId := range v.Neighbors {
+		remaining -= weights[idx]
+		if remaining < 0 {
+			return neighborVertexId
+		}
+	}
+	return v.Neighbors[len(v.Neighbors)-1]
+}
+
+// random returns a number in [0, 1) for the next step of the walk. It only
+// depends on the seed of the query, the walk and the step, so walks do not
+// depend on the order messages arrive in, and a query recovered from a
+// checkpoint takes the same steps again
+func (p *randomWalkProgram) random(walk RandomWalk) float64 {
+	const golden = 0x9e3779b97f4a7c15
+	state := uint64(int64(p.query.Param(RANDOM_WALK_SEED, 0)))
+	for _, value := range []uint64{
+		walk.Path[0], uint64(walk.Index), uint64(len(walk.Path)),
+	} {
+		state = hashVertexId(state + golden + value)
+	}
+	return float64(state>>11) / (1 << 53)
+}
+
+// PartialResult counts the walks ending at the worker's vertices
+func (p *randomWalkProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	numWalks := 0
+	for _, vertex := range vertices {
+		numWalks += len(vertex.CurrentValue.([][]uint64))
+	}
+	return numWalks
+}
+
+// MergeResults returns the number of walks
+func (p *randomWalkProgram) MergeResults(partials []interface{}) interface{} {
+	numWalks := 0
+	for _, partial := range partials {
+		numWalks += partial.(int)
+	}
+	return numWalks
+}
+
+// WriteResults writes a walk per line, as the ids of its vertices separated
+// by spaces. The walks are sorted, since the order they end in depends on
+// the order messages arrive in
+func (p *randomWalkProgram) WriteResults(
+	vertices map[uint64]*Vertex, writer io.Writer,
+) error {
+	vertexIds := make([]uint64, 0, len(vertices))
+	for vertexId := range vertices {
+		vertexIds = append(vertexIds, vertexId)
+	}
+	sort.Slice(
+		vertexIds, func(i, j int) bool {
+			return vertexIds[i] < vertexIds[j]
+		},
+	)
+
+	bufferedWriter := bufio.NewWriter(writer)
+	for _, vertexId := range vertexIds {
+		paths := append(
+			[][]uint64(nil), vertices[vertexId].CurrentValue.([][]uint64)...,
+		)
+		sort.Slice(
+			paths, func(i, j int) bool {
+				return isPathBefore(paths[i], paths[j])
+			},
+		)
+		for _, path := range paths {
+			line := make([]byte, 0, 8*len(path))
+			for idx, pathVertexId := range path {
+				if idx > 0 {
+					line = append(line, ' ')
+				}
+				line = strconv.AppendUint(line, pathVertexId, 10)
+			}
+			if _, err := bufferedWriter.Write(append(line, '\n')); err != nil {
+				return err
+			}
+		}
+	}
+	return bufferedWriter.Flush()
+}
+
+// isPathBefore orders paths by the ids of their vertices
+func isPathBefore(a []uint64, b []uint64) bool {
+	for idx := 0; idx < len(a) && idx < len(b); idx++ {
+		if a[idx] != b[idx] {
+			return a[idx] < b[idx]
+		}
+	}
+	return len(a) < len(b)
+}
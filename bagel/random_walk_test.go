@@ -0,0 +1,128 @@
+package bagel
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var testRandomWalkGraph = map[uint64][]uint64{
+	1: {2, 4},
+	2: {3},
+	3: {1},
+	4: {1, 2, 3},
+	5: {},
+}
+
+func TestRandomWalks(t *testing.T) {
+	query := Query{
+		QueryType: RANDOM_WALK,
+		Params: map[string]float64{
+			RANDOM_WALK_NUM_WALKS: 3, RANDOM_WALK_LENGTH: 5,
+		},
+	}
+	w, result := runTestQuery(t, query, testRandomWalkGraph)
+	if result != 15 {
+		t.Errorf("expected 15 walks but got %v", result)
+	}
+
+	var output bytes.Buffer
+	if err := w.program.(ResultWriter).WriteResults(
+		w.Vertices, &output,
+	); err != nil {
+		t.Fatalf("could not write the walks: %v", err)
+	}
+	walks := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(walks) != 15 {
+		t.Fatalf("expected 15 walks but got %q", walks)
+	}
+	for _, walk := range walks {
+		path := strings.Fields(walk)
+		if path[0] == "5" {
+			if len(path) != 1 {
+				t.Errorf("walk %q left a vertex without out-edges", walk)
+			}
+			continue
+		}
+		if len(path) != 5 {
+			t.Errorf("walk %q does not have 5 vertices", walk)
+		}
+		for idx := 1; idx < len(path); idx++ {
+			if !isTestRandomWalkEdge(path[idx-1], path[idx]) {
+				t.Errorf("walk %q does not follow the edges", walk)
+			}
+		}
+	}
+
+	// the walks only depend on the seed of the query
+	again, _ := runTestQuery(t, query, testRandomWalkGraph)
+	var againOutput bytes.Buffer
+	again.program.(ResultWriter).WriteResults(again.Vertices, &againOutput)
+	if output.String() != againOutput.String() {
+		t.Errorf("walks changed between runs of the same query")
+	}
+}
+
+func isTestRandomWalkEdge(source string, dest string) bool {
+	for id, edges := range testRandomWalkGraph {
+		for _, edge := range edges {
+			if source == strconv.FormatUint(id, 10) &&
+				dest == strconv.FormatUint(edge, 10) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestBiasedRandomWalksReturn(t *testing.T) {
+	// with a tiny p, walks go back to the vertex they came from
+	graph := map[uint64][]uint64{1: {2}, 2: {1, 3}, 3: {}}
+	w, _ := runTestQuery(
+		t, Query{
+			QueryType: RANDOM_WALK,
+			Params: map[string]float64{
+				RANDOM_WALK_NUM_WALKS: 5, RANDOM_WALK_LENGTH: 5,
+				RANDOM_WALK_RETURN: 1e-9,
+			},
+		}, graph,
+	)
+	walks := w.Vertices[1].CurrentValue.([][]uint64)
+	if len(walks) != 5 {
+		t.Fatalf("expected 5 walks ending at 1 but got %v", walks)
+	}
+	for _, walk := range walks {
+		if walk[0] != 1 || walk[1] != 2 || walk[2] != 1 || walk[3] != 2 {
+			t.Errorf("walk %v did not return", walk)
+		}
+	}
+}
+
+func TestRandomWalkValidation(t *testing.T) {
+	for _, params := range []map[string]float64{
+		{RANDOM_WALK_IN_OUT: 0},
+		{RANDOM_WALK_IN_OUT: math.NaN()},
+		{RANDOM_WALK_RETURN: math.NaN()},
+		{RANDOM_WALK_RETURN: math.Inf(1)},
+		{RANDOM_WALK_NUM_WALKS: 0},
+		{RANDOM_WALK_NUM_WALKS: 1.5},
+		{RANDOM_WALK_NUM_WALKS: math.NaN()},
+		{RANDOM_WALK_LENGTH: math.Inf(1)},
+		{RANDOM_WALK_LENGTH: math.NaN()},
+		{RANDOM_WALK_SEED: 0.5},
+		{RANDOM_WALK_SEED: math.NaN()},
+	} {
+		if _, err := NewVertexProgram(
+			Query{QueryType: RANDOM_WALK, Params: params},
+		); err == nil {
+			t.Errorf("random walk query with params %v is valid", params)
+		}
+	}
+	if _, err := NewVertexProgram(
+		Query{QueryType: RANDOM_WALK, Nodes: []uint64{1}},
+	); err == nil {
+		t.Errorf("random walk query of a vertex is valid")
+	}
+}
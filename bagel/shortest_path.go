@@ -0,0 +1,88 @@
+package bagel
+
+import (
+	"errors"
+	"math"
+)
+
+type shortestPathProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(SHORTEST_PATH, newShortestPathProgram)
+}
+
+func newShortestPathProgram(query Query) VertexProgram {
+	return &shortestPathProgram{query: query}
+}
+
+func (p *shortestPathProgram) Validate() error {
+	// the destination is optional if the query returns more than one vertex
+	if !p.query.IsTargetQuery() && len(p.query.Nodes) == 1 {
+		return nil
+	}
+	if len(p.query.Nodes) != 2 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	return nil
+}
+
+func (p *shortestPathProgram) InitialValue(v *Vertex) interface{} {
+	return math.MaxInt32
+}
+
+func (p *shortestPathProgram) InitialMessages(v *Vertex) []Message {
+	if !IsTargetVertex(v.Id, p.query.Nodes, SHORTEST_PATH_SOURCE) {
+		return nil
+	}
+	return []Message{{INITIALIZATION_VERTEX, v.Id, 0}}
+}
+
+func (p *shortestPathProgram) Compute(v *Vertex) []Message {
+	result := make([]Message, 0)
+	shortestNewPath := math.MaxInt32
+	predecessor := uint64(INITIALIZATION_VERTEX)
+	for _, message := range v.Messages {
+		pathLength := message.Value.(int) // cast to an int
+		v.PreviousValues[message.SourceVertexId] = pathLength
+		if pathLength < shortestNewPath {
+			shortestNewPath = pathLength
+			predecessor = message.SourceVertexId
+		}
+	}
+
+	if shortestNewPath < v.CurrentValue.(int) {
+		v.CurrentValue = shortestNewPath
+		v.Predecessor = predecessor
+		for _, neighborVertexId := range v.Neighbors {
+			newMessage := Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          shortestNewPath + 1,
+			}
+			result = append(result, newMessage)
+		}
+	}
+
+	// a vertex only has work to do when it is offered a shorter path
+	if len(result) == 0 {
+		v.VoteToHalt()
+	}
+	return result
+}
+
+func (p *shortestPathProgram) Result(v *Vertex) (interface{}, bool) {
+	return v.CurrentValue, IsTargetVertex(
+		v.Id, p.query.Nodes, SHORTEST_PATH_DEST,
+	)
+}
+
+// Combine keeps the shortest path, which is the only one Compute can use
+func (p *shortestPathProgram) Combine(a Message, b Message) Message {
+	return minMessage(a, b)
+}
+
+func (p *shortestPathProgram) PathEnds() (uint64, uint64) {
+	return p.query.Nodes[0], p.query.Nodes[1]
+}
@@ -0,0 +1,475 @@
+package bagel
+
+import (
+	"encoding/gob"
+	"errors"
+	coordgRPC "project/bagel/proto/coord"
+)
+
+// phases of a minimum spanning forest query, which repeat from
+// BORUVKA_MIN_EDGE to BORUVKA_UPDATE once per round of Borůvka
+const (
+	// every vertex finds its lightest edge to another supervertex and sends
+	// it to the root of its supervertex
+	BORUVKA_MIN_EDGE = "MinEdge"
+	// every root picks the lightest edge of its supervertex and points to
+	// the supervertex at its other end
+	BORUVKA_MERGE = "Merge"
+	// two roots pointing to each other chose the same edge, the smaller one
+	// stays a root. The other roots add their edge to the forest
+	BORUVKA_BREAK_CYCLES = "BreakCycles"
+	// the roots of the merged supervertices learn their new root from the
+	// roots they point to
+	BORUVKA_PROPAGATE = "Propagate"
+	// the roots send the new root to the vertices of their supervertex
+	BORUVKA_RELABEL = "Relabel"
+	// the vertices tell their neighbors about their new supervertex
+	BORUVKA_UPDATE = "Update"
+)
+
+// BORUVKA_MERGES is the aggregator counting the supervertices merging in a
+// round
+const BORUVKA_MERGES = "BoruvkaMerges"
+
+// SpanningForest is the result of a minimum spanning forest query
+type SpanningForest struct {
+	Weight   float64
+	NumTrees uint64
+}
+
+// FillQueryResult sends the weight of the forest as the Result, along with
+// its number of trees
+func (f SpanningForest) FillQueryResult(reply *coordgRPC.QueryResult) {
+	reply.Result = f.Weight
+	reply.NumTrees = f.NumTrees
+}
+
+// SpanningEdge is an undirected weighted edge, from the smaller vertex id to
+// the larger one
+type SpanningEdge struct {
+	Source uint64
+	Dest   uint64
+	Weight float64
+}
+
+// BoruvkaVertex is the value of a vertex in a minimum spanning forest query
+type BoruvkaVertex struct {
+	// root of the supervertex the vertex belongs to
+	Component uint64
+	// edges of the forest the vertex is an endpoint of
+	ForestEdges []SpanningEdge
+
+	// state of the roots during a round: the lightest edge of the
+	// supervertex, the root it points to, the vertices of the supervertex
+	// and the roots pointing to it
+	MinEdge    SpanningEdge
+	HasMinEdge bool
+	Pointer    uint64
+	Members    []uint64
+	Children   []uint64
+}
+
+// boruvkaNeighbor is what a vertex knows of a neighbor: the weight of the
+// lightest edge between them and the supervertex of the neighbor
+type boruvkaNeighbor struct {
+	Weight    float64
+	Component uint64
+}
+
+// boruvkaCandidate is the lightest edge from a vertex to another
+// supervertex, which it sends to its root
+type boruvkaCandidate struct {
+	Edge      SpanningEdge
+	Component uint64
+}
+
+// forestPartial is a worker's share of a SpanningForest
+type forestPartial struct {
+	Weight   float64
+	NumTrees uint64
+}
+
+// spanningForestProgram finds a minimum spanning forest of the graph,
+// ignoring the direction of the edges, with Borůvka's algorithm. The
+// supervertices of a round are the trees found so far, each represented by
+// a root vertex. Every round, each supervertex merges along its lightest edge
+// to another supervertex, until no edge is left between supervertices.
+// Every vertex keeps the supervertex of its neighbors in PreviousValues, and
+// removes the edges within its own supervertex from the graph, since they
+// are never used again. Ties between edges of the same weight are broken by
+// the ids of their endpoints, so that supervertices never merge in a cycle
+// longer than two
+type spanningForestProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(MINIMUM_SPANNING_FOREST, newSpanningForestProgram)
+
+	// vertex values are checkpointed, and messages and partial results are
+	// sent as an interface{}
+	gob.Register(BoruvkaVertex{})
+	gob.Register(SpanningEdge{})
+	gob.Register(boruvkaNeighbor{})
+	gob.Register(boruvkaCandidate{})
+	gob.Register(forestPartial{})
+}
+
+func newSpanningForestProgram(query Query) VertexProgram {
+	return &spanningForestProgram{query: query}
+}
+
+func (p *spanningForestProgram) Validate() error {
+	if p.query.IsTopKQuery() || len(p.query.Nodes) > 0 {
+		return errors.New("spanning forest returns the forest of the graph")
+	}
+	return nil
+}
+
+func (p *spanningForestProgram) InitialValue(v *Vertex) interface{} {
+	return BoruvkaVertex{Component: v.Id}
+}
+
+func (p *spanningForestProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	state := v.CurrentValue.(BoruvkaVertex)
+	var result []Message
+	switch v.Phase() {
+	case "":
+		result = p.sendWeights(v)
+	case BORUVKA_MIN_EDGE:
+		result = p.findMinEdge(v, state)
+	case BORUVKA_MERGE:
+		result = p.merge(v, &state)
+	case BORUVKA_BREAK_CYCLES:
+		result = p.breakCycles(v, &state)
+	case BORUVKA_PROPAGATE:
+		result = p.propagate(v, &state)
+	case BORUVKA_RELABEL:
+		result = p.relabel(v, &state)
+	case BORUVKA_UPDATE:
+		result = p.update(v, &state)
+	}
+	v.CurrentValue = state
+	return result
+}
+
+// sendWeights records the out-edges of the vertex, and sends their weight
+// to the out-neighbors for them to know their in-edges
+func (p *spanningForestProgram) sendWeights(v *Vertex) []Message {
+	result := make([]Message, 0, len(v.Neighbors))
+	for idx, neighborVertexId := range v.Neighbors {
+		if neighborVertexId == v.Id {
+			continue
+		}
+		p.addEdge(v, neighborVertexId, v.EdgeWeight(idx))
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          v.EdgeWeight(idx),
+			},
+		)
+	}
+	return result
+}
+
+// addEdge keeps the lightest edge to the neighbor
+func (p *spanningForestProgram) addEdge(
+	v *Vertex, neighborVertexId uint64, weight float64,
+) {
+	neighbor, exists := v.PreviousValues[neighborVertexId]
+	if exists && neighbor.(boruvkaNeighbor).Weight <= weight {
+		return
+	}
+	v.PreviousValues[neighborVertexId] = boruvkaNeighbor{
+		Weight: weight, Component: neighborVertexId,
+	}
+}
+
+// findMinEdge records the in-edges and supervertices of the neighbors and
+// prunes the edges within the supervertex, then sends the lightest edge to
+// another supervertex to the root. Every vertex writes to its root, for the
+// root to know the vertices of its supervertex
+func (p *spanningForestProgram) findMinEdge(
+	v *Vertex, state BoruvkaVertex,
+) []Message {
+	for _, message := range v.Messages {
+		switch value := message.Value.(type) {
+		case float64:
+			p.addEdge(v, message.SourceVertexId, value)
+		case uint64:
+			neighbor := v.PreviousValues[message.SourceVertexId].(boruvkaNeighbor)
+			neighbor.Component = value
+			v.PreviousValues[message.SourceVertexId] = neighbor
+		}
+	}
+
+	candidate := boruvkaCandidate{}
+	hasCandidate := false
+	for neighborVertexId, value := range v.PreviousValues {
+		neighbor := value.(boruvkaNeighbor)
+		if neighbor.Component == state.Component {
+			delete(v.PreviousValues, neighborVertexId)
+			v.RemoveEdge(v.Id, neighborVertexId)
+			continue
+		}
+		edge := newSpanningEdge(v.Id, neighborVertexId, neighbor.Weight)
+		if !hasCandidate || isLighterEdge(edge, candidate.Edge) {
+			candidate = boruvkaCandidate{
+				Edge: edge, Component: neighbor.Component,
+			}
+			hasCandidate = true
+		}
+	}
+
+	var value interface{}
+	if hasCandidate {
+		value = candidate
+	}
+	return []Message{
+		{
+			SourceVertexId: v.Id,
+			DestVertexId:   state.Component,
+			Value:          value,
+		},
+	}
+}
+
+func newSpanningEdge(a uint64, b uint64, weight float64) SpanningEdge {
+	if a > b {
+		a, b = b, a
+	}
+	return SpanningEdge{Source: a, Dest: b, Weight: weight}
+}
+
+// isLighterEdge orders the edges by weight, then by the ids of their
+// endpoints
+func isLighterEdge(a SpanningEdge, b SpanningEdge) bool {
+	if a.Weight != b.Weight {
+		return a.Weight < b.Weight
+	}
+	if a.Source != b.Source {
+		return a.Source < b.Source
+	}
+	return a.Dest < b.Dest
+}
+
+// merge picks the lightest edge of the supervertex of a root, and points the
+// root to the supervertex at the other end of the edge
+func (p *spanningForestProgram) merge(
+	v *Vertex, state *BoruvkaVertex,
+) []Message {
+	state.Pointer = v.Id
+	state.HasMinEdge = false
+	state.Members = make([]uint64, 0, len(v.Messages))
+	for _, message := range v.Messages {
+		state.Members = append(state.Members, message.SourceVertexId)
+		candidate, isCandidate := message.Value.(boruvkaCandidate)
+		if !isCandidate {
+			continue
+		}
+		if !state.HasMinEdge || isLighterEdge(candidate.Edge, state.MinEdge) {
+			state.MinEdge = candidate.Edge
+			state.HasMinEdge = true
+			state.Pointer = candidate.Component
+		}
+	}
+	if !state.HasMinEdge {
+		return nil
+	}
+
+	v.Aggregate(BORUVKA_MERGES, 1)
+	return []Message{
+		{SourceVertexId: v.Id, DestVertexId: state.Pointer, Value: v.Id},
+	}
+}
+
+// breakCycles makes the smaller of two roots pointing to each other a root
+// again. The roots that still point to another one add their edge to the
+// forest, and the others send their id down the roots pointing to them
+func (p *spanningForestProgram) breakCycles(
+	v *Vertex, state *BoruvkaVertex,
+) []Message {
+	state.Children = make([]uint64, 0, len(v.Messages))
+	for _, message := range v.Messages {
+		childVertexId := message.SourceVertexId
+		if childVertexId != state.Pointer {
+			state.Children = append(state.Children, childVertexId)
+		} else if v.Id < childVertexId {
+			state.Pointer = v.Id
+			state.HasMinEdge = false
+			state.Children = append(state.Children, childVertexId)
+		}
+	}
+
+	if state.HasMinEdge {
+		edge := state.MinEdge
+		return []Message{
+			{SourceVertexId: v.Id, DestVertexId: edge.Source, Value: edge},
+			{SourceVertexId: v.Id, DestVertexId: edge.Dest, Value: edge},
+		}
+	}
+	if state.Pointer == v.Id {
+		return p.sendToChildren(v, *state)
+	}
+	return nil
+}
+
+func (p *spanningForestProgram) sendToChildren(
+	v *Vertex, state BoruvkaVertex,
+) []Message {
+	result := make([]Message, 0, len(state.Children))
+	for _, childVertexId := range state.Children {
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   childVertexId,
+				Value:          state.Pointer,
+			},
+		)
+	}
+	return result
+}
+
+// propagate adds the edges of the forest to their endpoints, and passes the
+// new root down the roots pointing to each other
+func (p *spanningForestProgram) propagate(
+	v *Vertex, state *BoruvkaVertex,
+) []Message {
+	var result []Message
+	for _, message := range v.Messages {
+		switch value := message.Value.(type) {
+		case SpanningEdge:
+			state.ForestEdges = append(state.ForestEdges, value)
+		case uint64:
+			state.Pointer = value
+			result = append(result, p.sendToChildren(v, *state)...)
+		}
+	}
+	return result
+}
+
+// relabel sends the new root of the supervertex to its vertices
+func (p *spanningForestProgram) relabel(
+	v *Vertex, state *BoruvkaVertex,
+) []Message {
+	result := make([]Message, 0, len(state.Members))
+	for _, memberVertexId := range state.Members {
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   memberVertexId,
+				Value:          state.Pointer,
+			},
+		)
+	}
+	state.Members = nil
+	state.Children = nil
+	return result
+}
+
+// update moves the vertex to its new supervertex, and tells its neighbors
+func (p *spanningForestProgram) update(
+	v *Vertex, state *BoruvkaVertex,
+) []Message {
+	if len(v.Messages) == 0 {
+		return nil
+	}
+	component := v.Messages[0].Value.(uint64)
+	if component == state.Component {
+		return nil
+	}
+	state.Component = component
+
+	result := make([]Message, 0, len(v.PreviousValues))
+	for neighborVertexId := range v.PreviousValues {
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          component,
+			},
+		)
+	}
+	return result
+}
+
+func (p *spanningForestProgram) Aggregators() map[string]string {
+	return map[string]string{BORUVKA_MERGES: AGGREGATE_COUNT}
+}
+
+// MasterCompute moves the query through the phases of every round, and ends
+// the query once a round merges no supervertex
+func (p *spanningForestProgram) MasterCompute(master *MasterContext) {
+	switch master.Phase() {
+	case "":
+		master.SetPhase(BORUVKA_MIN_EDGE)
+	case BORUVKA_MIN_EDGE:
+		master.SetPhase(BORUVKA_MERGE)
+	case BORUVKA_MERGE:
+		if master.Aggregated[BORUVKA_MERGES] > 0 {
+			master.SetPhase(BORUVKA_BREAK_CYCLES)
+		}
+	case BORUVKA_BREAK_CYCLES:
+		master.SetPhase(BORUVKA_PROPAGATE)
+	case BORUVKA_PROPAGATE:
+		// the new root travels down the roots pointing to each other one
+		// superstep at a time
+		if master.AllWorkersInactive {
+			master.SetPhase(BORUVKA_RELABEL)
+		}
+	case BORUVKA_RELABEL:
+		master.SetPhase(BORUVKA_UPDATE)
+	case BORUVKA_UPDATE:
+		master.SetPhase(BORUVKA_MIN_EDGE)
+	}
+}
+
+// PartialResult returns the weight of the forest edges of the worker's
+// vertices, each counted at its source, and the number of trees rooted at
+// them
+func (p *spanningForestProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	partial := forestPartial{}
+	for _, vertex := range vertices {
+		state := vertex.CurrentValue.(BoruvkaVertex)
+		if state.Component == vertex.Id {
+			partial.NumTrees++
+		}
+		for _, edge := range state.ForestEdges {
+			if edge.Source == vertex.Id {
+				partial.Weight += edge.Weight
+			}
+		}
+	}
+	return partial
+}
+
+// ResultEdges returns the forest edges of the vertex it is the source of, so
+// that VertexValues queries report every edge of the forest once
+func (p *spanningForestProgram) ResultEdges(v *Vertex) []SpanningEdge {
+	forestEdges := v.CurrentValue.(BoruvkaVertex).ForestEdges
+	edges := make([]SpanningEdge, 0, len(forestEdges))
+	for _, edge := range forestEdges {
+		if edge.Source == v.Id {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// MergeResults returns the SpanningForest of the graph
+func (p *spanningForestProgram) MergeResults(
+	partials []interface{},
+) interface{} {
+	result := SpanningForest{}
+	for _, partial := range partials {
+		forest := partial.(forestPartial)
+		result.Weight += forest.Weight
+		result.NumTrees += forest.NumTrees
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package bagel
+
+import (
+	"math"
+	"project/database/mongodb"
+	"sort"
+	"testing"
+)
+
+// the minimum spanning tree of {1, 2, 3, 4} is 1-2, 3-4 and 2-3 of weight 4,
+// the heavier edge 2 -> 1 is ignored. {5, 6} weighs 2.5, 7 has no edges, and
+// any two edges of the triangle {8, 9, 10} are a minimum spanning tree
+var testSpanningForestGraph = []mongodb.Vertex{
+	{ID: 1, Edges: []uint64{2, 3}, Weights: []float64{1, 4}},
+	{ID: 2, Edges: []uint64{3, 1}, Weights: []float64{2, 5}},
+	{ID: 3, Edges: []uint64{4}, Weights: []float64{1}},
+	{ID: 4, Edges: []uint64{1}, Weights: []float64{3}},
+	{ID: 5, Edges: []uint64{6}, Weights: []float64{2.5}},
+	{ID: 6, Edges: []uint64{}, Weights: []float64{}},
+	{ID: 7, Edges: []uint64{}, Weights: []float64{}},
+	{ID: 8, Edges: []uint64{9}, Weights: []float64{1}},
+	{ID: 9, Edges: []uint64{10}, Weights: []float64{1}},
+	{ID: 10, Edges: []uint64{8}, Weights: []float64{1}},
+}
+
+func TestSpanningForest(t *testing.T) {
+	w, result := runTestQueryOnVertices(
+		t, Query{QueryType: MINIMUM_SPANNING_FOREST}, testSpanningForestGraph,
+	)
+	expected := SpanningForest{Weight: 8.5, NumTrees: 4}
+	if result != expected {
+		t.Errorf("expected spanning forest %v but got %v", expected, result)
+	}
+
+	// the edges within the trees are pruned as they merge
+	for id, vertex := range w.Vertices {
+		if len(vertex.Neighbors) > 0 || len(vertex.InNeighbors) > 0 {
+			t.Errorf(
+				"vertex %v kept edges to %v and from %v", id,
+				vertex.Neighbors, vertex.InNeighbors,
+			)
+		}
+	}
+}
+
+func TestSpanningForestEdges(t *testing.T) {
+	w, _ := runTestQueryOnVertices(
+		t, Query{
+			QueryType:  MINIMUM_SPANNING_FOREST,
+			ResultMode: RESULT_VERTEX_VALUES,
+		}, testSpanningForestGraph,
+	)
+
+	var result VertexValuesResult
+	w.GetVertexValues(VertexValuesRequest{}, &result)
+	edges := result.Edges
+	if len(edges) != 6 {
+		t.Fatalf("expected 6 forest edges but got %v", edges)
+	}
+	sort.Slice(
+		edges, func(i, j int) bool {
+			if edges[i].Source != edges[j].Source {
+				return edges[i].Source < edges[j].Source
+			}
+			return edges[i].Dest < edges[j].Dest
+		},
+	)
+	expected := []SpanningEdge{
+		{Source: 1, Dest: 2, Weight: 1}, {Source: 2, Dest: 3, Weight: 2},
+		{Source: 3, Dest: 4, Weight: 1}, {Source: 5, Dest: 6, Weight: 2.5},
+	}
+	for idx, edge := range expected {
+		if edges[idx] != edge {
+			t.Errorf("expected forest edges %v but got %v", expected, edges)
+		}
+	}
+
+	// any two edges of the triangle are a minimum spanning tree
+	for _, edge := range edges[len(expected):] {
+		if edge.Source < 8 || edge.Dest > 10 || edge.Weight != 1 {
+			t.Errorf("edge %v is not in the triangle", edge)
+		}
+	}
+	if edges[4] == edges[5] {
+		t.Errorf("triangle edge %v is reported twice", edges[4])
+	}
+}
+
+func TestSpanningForestOnUnweightedGraph(t *testing.T) {
+	graph := map[uint64][]uint64{
+		1: {2, 3},
+		2: {3},
+		3: {1},
+		4: {3},
+	}
+	_, result := runTestQuery(
+		t, Query{QueryType: MINIMUM_SPANNING_FOREST}, graph,
+	)
+	forest := result.(SpanningForest)
+	if math.Abs(forest.Weight-3) > 1e-9 || forest.NumTrees != 1 {
+		t.Errorf("expected a spanning tree of weight 3 but got %v", forest)
+	}
+}
+
+func TestSpanningForestValidation(t *testing.T) {
+	if _, err := NewVertexProgram(
+		Query{QueryType: MINIMUM_SPANNING_FOREST, Nodes: []uint64{1}},
+	); err == nil {
+		t.Errorf("spanning forest query of a vertex is valid")
+	}
+	if _, err := NewVertexProgram(
+		Query{
+			QueryType: MINIMUM_SPANNING_FOREST, ResultMode: RESULT_TOP_K,
+			TopK: 10,
+		},
+	); err == nil {
+		t.Errorf("spanning forest ranking is valid")
+	}
+}
@@ -0,0 +1,164 @@
+package bagel
+
+import "errors"
+
+// phases of a strongly connected components query
+const (
+	SCC_FORWARD  = "Forward"
+	SCC_BACKWARD = "Backward"
+)
+
+// SCC_REMAINING is the aggregator counting the vertices that are not in a
+// component yet at the start of a forward phase
+const SCC_REMAINING = "SCCRemaining"
+
+// stronglyConnectedComponentsProgram finds strongly connected components with
+// forward-backward coloring. In a forward phase, every vertex not yet in a
+// component takes the smallest id of the vertices that reach it along
+// out-edges as its color. In the backward phase, every vertex whose color is
+// its own id is the root of a component, which holds the vertices of the same
+// color that reach the root, found by following in-edges back from the root.
+// Phases alternate until every vertex is in a component, whose id is the id
+// of its root
+type stronglyConnectedComponentsProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(
+		STRONGLY_CONNECTED_COMPONENTS, newStronglyConnectedComponentsProgram,
+	)
+}
+
+func newStronglyConnectedComponentsProgram(query Query) VertexProgram {
+	return &stronglyConnectedComponentsProgram{query: query}
+}
+
+func (p *stronglyConnectedComponentsProgram) Validate() error {
+	if len(p.query.Nodes) > 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	return nil
+}
+
+// InitialValue marks the vertex as not in a component yet
+func (p *stronglyConnectedComponentsProgram) InitialValue(
+	v *Vertex,
+) interface{} {
+	return uint64(INITIALIZATION_VERTEX)
+}
+
+// Compute runs the forward phase from superstep 1 on. The color of a vertex
+// is kept in PreviousValues under its own id until it joins a component
+func (p *stronglyConnectedComponentsProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	// vertices in a component are removed from the graph
+	if v.CurrentValue.(uint64) != INITIALIZATION_VERTEX {
+		return nil
+	}
+
+	if v.Phase() == SCC_BACKWARD {
+		return p.computeBackward(v)
+	}
+	return p.computeForward(v)
+}
+
+func (p *stronglyConnectedComponentsProgram) computeForward(
+	v *Vertex,
+) []Message {
+	if v.IsPhaseStart() {
+		v.Aggregate(SCC_REMAINING, 1)
+		v.PreviousValues[v.Id] = v.Id
+		return p.sendColor(v, v.Neighbors, v.Id)
+	}
+
+	color := v.PreviousValues[v.Id].(uint64)
+	minColor := color
+	for _, message := range v.Messages {
+		if messageColor := message.Value.(uint64); messageColor < minColor {
+			minColor = messageColor
+		}
+	}
+	if minColor == color {
+		return nil
+	}
+	v.PreviousValues[v.Id] = minColor
+	return p.sendColor(v, v.Neighbors, minColor)
+}
+
+func (p *stronglyConnectedComponentsProgram) computeBackward(
+	v *Vertex,
+) []Message {
+	color := v.PreviousValues[v.Id].(uint64)
+
+	isInComponent := v.IsPhaseStart() && color == v.Id
+	for _, message := range v.Messages {
+		if message.Value.(uint64) == color {
+			isInComponent = true
+		}
+	}
+	if !isInComponent {
+		return nil
+	}
+
+	v.CurrentValue = color
+	delete(v.PreviousValues, v.Id)
+	return p.sendColor(v, v.InNeighbors, color)
+}
+
+// sendColor sends the color to the neighbors, skipping self-loops
+func (p *stronglyConnectedComponentsProgram) sendColor(
+	v *Vertex, neighbors []uint64, color uint64,
+) []Message {
+	result := make([]Message, 0, len(neighbors))
+	for _, neighborVertexId := range neighbors {
+		if neighborVertexId == v.Id {
+			continue
+		}
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          color,
+			},
+		)
+	}
+	return result
+}
+
+func (p *stronglyConnectedComponentsProgram) Aggregators() map[string]string {
+	return map[string]string{SCC_REMAINING: AGGREGATE_COUNT}
+}
+
+// MasterCompute moves to the backward phase once the colors stop changing,
+// and back to a forward phase once the components of the roots are found.
+// The query ends when a forward phase starts with every vertex in a component
+func (p *stronglyConnectedComponentsProgram) MasterCompute(
+	master *MasterContext,
+) {
+	if !master.AllWorkersInactive {
+		return
+	}
+
+	if master.Phase() == SCC_BACKWARD {
+		master.SetPhase(SCC_FORWARD)
+		return
+	}
+	if master.IsPhaseStart && master.Aggregated[SCC_REMAINING] == 0 {
+		return
+	}
+	master.SetPhase(SCC_BACKWARD)
+}
+
+func (p *stronglyConnectedComponentsProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	return partialComponentSizes(p.query, vertices)
+}
+
+func (p *stronglyConnectedComponentsProgram) MergeResults(
+	partials []interface{},
+) interface{} {
+	return mergeComponentSizes(p.query, partials)
+}
@@ -0,0 +1,68 @@
+package bagel
+
+import (
+	"reflect"
+	"testing"
+)
+
+// three strongly connected components, {1, 2, 3}, {4, 5} and {6}, where
+// {1, 2, 3} reaches both others and 6 has a self-loop
+var testStronglyConnectedGraph = map[uint64][]uint64{
+	1: {2},
+	2: {3, 4},
+	3: {1},
+	4: {5, 6},
+	5: {4},
+	6: {6},
+}
+
+func TestStronglyConnectedComponentsLabels(t *testing.T) {
+	w, _ := runTestQuery(
+		t, Query{QueryType: STRONGLY_CONNECTED_COMPONENTS},
+		testStronglyConnectedGraph,
+	)
+
+	expected := map[uint64]uint64{1: 1, 2: 1, 3: 1, 4: 4, 5: 4, 6: 6}
+	for id, componentId := range expected {
+		if w.Vertices[id].CurrentValue != componentId {
+			t.Errorf(
+				"vertex %v: expected component %v but got %v", id,
+				componentId, w.Vertices[id].CurrentValue,
+			)
+		}
+	}
+}
+
+func TestStronglyConnectedComponentOfVertex(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{QueryType: STRONGLY_CONNECTED_COMPONENTS, Nodes: []uint64{5}},
+		testStronglyConnectedGraph,
+	)
+	expected := ComponentResult{ComponentId: 4, Size: 2}
+	if result != expected {
+		t.Errorf("expected component %v but got %v", expected, result)
+	}
+}
+
+func TestStronglyConnectedComponentsHistogram(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{QueryType: STRONGLY_CONNECTED_COMPONENTS},
+		testStronglyConnectedGraph,
+	)
+	expected := ComponentHistogram{3: 1, 2: 1, 1: 1}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected histogram %v but got %v", expected, result)
+	}
+}
+
+func TestStronglyConnectedComponentsOfChain(t *testing.T) {
+	// every vertex of a chain is its own component, found one per round
+	graph := map[uint64][]uint64{1: {2}, 2: {3}, 3: {}}
+	_, result := runTestQuery(
+		t, Query{QueryType: STRONGLY_CONNECTED_COMPONENTS}, graph,
+	)
+	expected := ComponentHistogram{1: 3}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected histogram %v but got %v", expected, result)
+	}
+}
@@ -0,0 +1,79 @@
+package bagel
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// RankedVertex is an entry of the ranking returned by a TopK query
+type RankedVertex struct {
+	VertexId uint64
+	Score    float64
+}
+
+// RankedResult is implemented by the results of TopK queries holding more
+// than a ranking of the vertices, such as the scores behind it. Ranked
+// returns the ranking sent back as the Ranking of the query
+type RankedResult interface {
+	Ranked() []RankedVertex
+}
+
+// rankedBefore reports whether a is ranked above b. Vertices with the same
+// score are ranked by vertex id so the ranking does not depend on how the
+// graph is partitioned
+func rankedBefore(a RankedVertex, b RankedVertex) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return a.VertexId < b.VertexId
+}
+
+// rankingHeap is a min-heap holding the k best vertices seen so far, with the
+// worst of them at the root
+type rankingHeap []RankedVertex
+
+func (h rankingHeap) Len() int           { return len(h) }
+func (h rankingHeap) Less(i, j int) bool { return rankedBefore(h[j], h[i]) }
+func (h rankingHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *rankingHeap) Push(x interface{}) {
+	*h = append(*h, x.(RankedVertex))
+}
+
+func (h *rankingHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// topK returns the k best ranked vertices, best first
+func topK(candidates []RankedVertex, k int) []RankedVertex {
+	h := make(rankingHeap, 0, k+1)
+	for _, candidate := range candidates {
+		if len(h) < k {
+			heap.Push(&h, candidate)
+		} else if k > 0 && rankedBefore(candidate, h[0]) {
+			h[0] = candidate
+			heap.Fix(&h, 0)
+		}
+	}
+
+	ranking := []RankedVertex(h)
+	sort.Slice(
+		ranking, func(i, j int) bool {
+			return rankedBefore(ranking[i], ranking[j])
+		},
+	)
+	return ranking
+}
+
+// mergeTopK merges the local rankings of the workers into the global top k
+func mergeTopK(rankings [][]RankedVertex, k int) []RankedVertex {
+	candidates := make([]RankedVertex, 0)
+	for _, ranking := range rankings {
+		candidates = append(candidates, ranking...)
+	}
+	return topK(candidates, k)
+}
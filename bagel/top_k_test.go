@@ -0,0 +1,66 @@
+package bagel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	candidates := []RankedVertex{
+		{VertexId: 1, Score: 0.5},
+		{VertexId: 2, Score: 3},
+		{VertexId: 3, Score: 1},
+		{VertexId: 4, Score: 3},
+		{VertexId: 5, Score: 2},
+	}
+
+	expected := []RankedVertex{
+		{VertexId: 2, Score: 3},
+		{VertexId: 4, Score: 3},
+		{VertexId: 5, Score: 2},
+	}
+	if ranking := topK(candidates, 3); !reflect.DeepEqual(ranking, expected) {
+		t.Errorf("expected ranking %v but got %v", expected, ranking)
+	}
+	if ranking := topK(candidates, 10); len(ranking) != len(candidates) {
+		t.Errorf("expected all %v candidates to be ranked", len(candidates))
+	}
+}
+
+func TestMergeTopK(t *testing.T) {
+	rankings := [][]RankedVertex{
+		{{VertexId: 1, Score: 5}, {VertexId: 3, Score: 1}},
+		{{VertexId: 2, Score: 4}, {VertexId: 4, Score: 2}},
+	}
+
+	expected := []RankedVertex{
+		{VertexId: 1, Score: 5},
+		{VertexId: 2, Score: 4},
+		{VertexId: 4, Score: 2},
+	}
+	if ranking := mergeTopK(rankings, 3); !reflect.DeepEqual(ranking, expected) {
+		t.Errorf("expected ranking %v but got %v", expected, ranking)
+	}
+}
+
+func TestDegreeTopK(t *testing.T) {
+	graph := map[uint64][]uint64{
+		1: {2, 3, 4},
+		2: {3},
+		3: {},
+		4: {3},
+	}
+	w, _ := runTestQuery(
+		t, Query{QueryType: DEGREE, ResultMode: RESULT_TOP_K, TopK: 2}, graph,
+	)
+
+	var result TopKResult
+	w.GetTopK(TopKRequest{K: 2}, &result)
+	expected := []RankedVertex{
+		{VertexId: 1, Score: 3},
+		{VertexId: 3, Score: 3},
+	}
+	if !reflect.DeepEqual(result.Ranking, expected) {
+		t.Errorf("expected ranking %v but got %v", expected, result.Ranking)
+	}
+}
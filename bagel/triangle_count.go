@@ -0,0 +1,158 @@
+package bagel
+
+import (
+	"encoding/gob"
+	"errors"
+)
+
+// triangleCountProgram counts the triangles of the graph, ignoring the
+// direction of the edges. The value of a vertex is the number of triangles
+// it is part of. A query returns the local clustering coefficient of the
+// queried vertex, or the number of triangles in the graph if no vertex is
+// queried.
+//
+// Every triangle v < u < w is found once, by u: at superstep 1, v sends u
+// its neighbors greater than u, and at superstep 2, u finds w among its own
+// neighbors and tells v and w about the triangle. To keep the message volume
+// down, v sends each neighbor a single list and u sends each vertex a single
+// count. There is no Combiner since the lists cannot be merged without
+// losing their source
+type triangleCountProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(TRIANGLE_COUNT, newTriangleCountProgram)
+
+	// neighbor lists are sent between workers as the Value of a Message
+	gob.Register([]uint64{})
+}
+
+func newTriangleCountProgram(query Query) VertexProgram {
+	return &triangleCountProgram{query: query}
+}
+
+func (p *triangleCountProgram) Validate() error {
+	if len(p.query.Nodes) > 1 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	return nil
+}
+
+func (p *triangleCountProgram) InitialValue(v *Vertex) interface{} {
+	return 0
+}
+
+func (p *triangleCountProgram) Compute(v *Vertex) []Message {
+	defer v.VoteToHalt()
+
+	if v.SuperStepNum() <= 1 {
+		return p.sendNeighbors(v)
+	}
+
+	neighbors := v.UndirectedNeighbors()
+	isNeighbor := make(map[uint64]bool, len(neighbors))
+	for _, neighborVertexId := range neighbors {
+		isNeighbor[neighborVertexId] = true
+	}
+
+	// triangles found by the vertex, counted for each of their other vertices
+	triangles := make(map[uint64]int)
+	for _, message := range v.Messages {
+		switch value := message.Value.(type) {
+		case int:
+			// triangles found by another vertex
+			v.CurrentValue = v.CurrentValue.(int) + value
+		case []uint64:
+			for _, candidateVertexId := range value {
+				if isNeighbor[candidateVertexId] {
+					v.CurrentValue = v.CurrentValue.(int) + 1
+					triangles[message.SourceVertexId]++
+					triangles[candidateVertexId]++
+				}
+			}
+		}
+	}
+
+	result := make([]Message, 0, len(triangles))
+	for vertexId, numTriangles := range triangles {
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   vertexId,
+				Value:          numTriangles,
+			},
+		)
+	}
+	return result
+}
+
+// sendNeighbors sends every neighbor u greater than the vertex the neighbors
+// greater than u, which are the candidates for the third vertex of a triangle
+func (p *triangleCountProgram) sendNeighbors(v *Vertex) []Message {
+	neighbors := v.UndirectedNeighbors()
+	result := make([]Message, 0, len(neighbors))
+	for idx, neighborVertexId := range neighbors {
+		candidates := neighbors[idx+1:]
+		if neighborVertexId < v.Id || len(candidates) == 0 {
+			continue
+		}
+		result = append(
+			result, Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          candidates,
+			},
+		)
+	}
+	return result
+}
+
+// clusteringCoefficient returns the fraction of the pairs of neighbors of
+// the vertex that are connected
+func (p *triangleCountProgram) clusteringCoefficient(v *Vertex) float64 {
+	degree := len(v.UndirectedNeighbors())
+	if degree < 2 {
+		return 0
+	}
+	return 2 * float64(v.CurrentValue.(int)) / float64(degree*(degree-1))
+}
+
+// PartialResult returns the clustering coefficient of the queried vertex if
+// it is on this worker, or otherwise the number of triangles of the worker's
+// vertices
+func (p *triangleCountProgram) PartialResult(
+	vertices map[uint64]*Vertex,
+) interface{} {
+	if len(p.query.Nodes) == 1 {
+		if vertex, exists := vertices[p.query.Nodes[0]]; exists {
+			return p.clusteringCoefficient(vertex)
+		}
+		return nil
+	}
+
+	numTriangles := 0
+	for _, vertex := range vertices {
+		numTriangles += vertex.CurrentValue.(int)
+	}
+	return numTriangles
+}
+
+// MergeResults counts every triangle once, since each of its vertices
+// counted it
+func (p *triangleCountProgram) MergeResults(
+	partials []interface{},
+) interface{} {
+	if len(p.query.Nodes) == 1 {
+		if len(partials) == 0 {
+			return nil
+		}
+		return partials[0]
+	}
+
+	numTriangles := 0
+	for _, partial := range partials {
+		numTriangles += partial.(int)
+	}
+	return numTriangles / 3
+}
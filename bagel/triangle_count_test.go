@@ -0,0 +1,52 @@
+package bagel
+
+import (
+	"math"
+	"testing"
+)
+
+// two triangles, {1, 2, 3} and {2, 3, 4}, sharing the edge between 2 and 3,
+// with edges in both directions, a self-loop and a dangling vertex 5
+var testTrianglesGraph = map[uint64][]uint64{
+	1: {2, 3},
+	2: {3, 1},
+	3: {4, 3},
+	4: {2, 5},
+	5: {},
+}
+
+func TestTriangleCountPerVertex(t *testing.T) {
+	w, _ := runTestQuery(
+		t, Query{QueryType: TRIANGLE_COUNT}, testTrianglesGraph,
+	)
+
+	expected := map[uint64]int{1: 1, 2: 2, 3: 2, 4: 1, 5: 0}
+	for id, numTriangles := range expected {
+		if w.Vertices[id].CurrentValue != numTriangles {
+			t.Errorf(
+				"vertex %v: expected %v triangles but got %v", id,
+				numTriangles, w.Vertices[id].CurrentValue,
+			)
+		}
+	}
+}
+
+func TestTriangleCountOfGraph(t *testing.T) {
+	_, result := runTestQuery(
+		t, Query{QueryType: TRIANGLE_COUNT}, testTrianglesGraph,
+	)
+	if result != 2 {
+		t.Errorf("expected 2 triangles but got %v", result)
+	}
+}
+
+func TestClusteringCoefficient(t *testing.T) {
+	// vertex 4 has neighbors 2, 3 and 5, of which only 2 and 3 are connected
+	_, result := runTestQuery(
+		t, Query{QueryType: TRIANGLE_COUNT, Nodes: []uint64{4}},
+		testTrianglesGraph,
+	)
+	if math.Abs(result.(float64)-1.0/3) > float64EqualityThreshold {
+		t.Errorf("expected clustering coefficient 1/3 but got %v", result)
+	}
+}
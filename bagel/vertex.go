@@ -3,6 +3,7 @@ package bagel
 import (
 	"log"
 	"math"
+	"sort"
 )
 
 const (
@@ -13,12 +14,24 @@ const (
 
 // Vertex stores intermediate calculation data about the vertex
 type Vertex struct {
-	Id             uint64
-	Neighbors      []uint64
+	Id        uint64
+	Neighbors []uint64
+	// InNeighbors holds the sources of the edges to the vertex
+	InNeighbors []uint64
+	// EdgeWeights holds the weight of the edge to each of the Neighbors, or
+	// is empty if the graph is unweighted
+	EdgeWeights    []float64
 	PreviousValues map[uint64]interface{}
 	CurrentValue   interface{}
-	Messages       []Message
-	IsActive       bool
+	// Predecessor is the vertex whose message last updated CurrentValue,
+	// or INITIALIZATION_VERTEX if there is none
+	Predecessor uint64
+	Messages    []Message
+	// IsActive is false once the vertex votes to halt, until it receives a
+	// message
+	IsActive bool
+	// state of the superstep shared by the vertices of the worker
+	superStep *superStepContext
 }
 
 // VertexCheckpoint stores Vertex information that needs to be restored upon
@@ -26,8 +39,12 @@ type Vertex struct {
 type VertexCheckpoint struct {
 	Id             uint64
 	Neighbors      []uint64
+	InNeighbors    []uint64
+	EdgeWeights    []float64
 	PreviousValues map[uint64]interface{}
 	CurrentValue   interface{}
+	Predecessor    uint64
+	IsActive       bool
 }
 
 func NewVertex(id uint64, neighbors []uint64) *Vertex {
@@ -35,96 +52,82 @@ func NewVertex(id uint64, neighbors []uint64) *Vertex {
 		Id:             id,
 		Neighbors:      neighbors,
 		PreviousValues: make(map[uint64]interface{}),
+		Predecessor:    INITIALIZATION_VERTEX,
 		Messages:       make([]Message, 0),
-		IsActive:       false,
+		IsActive:       true,
 	}
 }
 
-func NewPageRankVertex(id uint64, neighbors []uint64) *Vertex {
-	prVertex := NewVertex(id, neighbors)
-	prVertex.CurrentValue = float64(0)
-	return prVertex
+// EdgeWeight returns the weight of the edge to v.Neighbors[idx], which is 1
+// if the graph is unweighted
+func (v *Vertex) EdgeWeight(idx int) float64 {
+	if idx >= len(v.EdgeWeights) {
+		return 1
+	}
+	return v.EdgeWeights[idx]
 }
 
-func NewShortestPathVertex(id uint64, neighbors []uint64, value int) *Vertex {
-	spVertex := NewVertex(id, neighbors)
-	spVertex.CurrentValue = value
-	return spVertex
+// UndirectedNeighbors returns the sorted ids of the vertices with an edge to
+// or from the vertex, without self-loops
+func (v *Vertex) UndirectedNeighbors() []uint64 {
+	seen := make(map[uint64]bool, len(v.Neighbors)+len(v.InNeighbors))
+	neighbors := make([]uint64, 0, len(v.Neighbors)+len(v.InNeighbors))
+	for _, edges := range [][]uint64{v.Neighbors, v.InNeighbors} {
+		for _, neighborVertexId := range edges {
+			if neighborVertexId == v.Id || seen[neighborVertexId] {
+				continue
+			}
+			seen[neighborVertexId] = true
+			neighbors = append(neighbors, neighborVertexId)
+		}
+	}
+	sort.Slice(
+		neighbors, func(i, j int) bool {
+			return neighbors[i] < neighbors[j]
+		},
+	)
+	return neighbors
 }
 
 func (v *Vertex) SetSuperStepInfo(messages []Message) {
 	v.Messages = messages
 }
 
-func (v *Vertex) Compute(queryType string) []Message {
-	var result []Message
-	switch queryType {
-	case PAGE_RANK:
-		result = v.ComputePageRank()
-	case SHORTEST_PATH:
-		result = v.ComputeShortestPath()
-	}
-	v.IsActive = len(result) > 0
-	return result
+// Run runs one superstep of program on the vertex, which stays active for
+// the next superstep unless the program votes to halt
+func (v *Vertex) Run(program VertexProgram) []Message {
+	v.IsActive = true
+	return program.Compute(v)
 }
 
-func (v *Vertex) ComputeShortestPath() []Message {
-	result := make([]Message, 0)
-	shortestNewPath := math.MaxInt32
-	for _, message := range v.Messages {
-		pathLength := message.Value.(int) // cast to an int
-		v.PreviousValues[message.SourceVertexId] = pathLength
-		if pathLength < shortestNewPath {
-			shortestNewPath = pathLength
-		}
-	}
-
-	if shortestNewPath < v.CurrentValue.(int) {
-		v.CurrentValue = shortestNewPath
-		for _, neighborVertexId := range v.Neighbors {
-			newMessage := Message{
-				SourceVertexId: v.Id,
-				DestVertexId:   neighborVertexId,
-				Value:          shortestNewPath + 1,
-			}
-			result = append(result, newMessage)
-		}
-	}
-	return result
+// VoteToHalt deactivates the vertex, which is not computed again until it
+// receives a message. The query ends once every vertex has voted to halt and
+// no messages are in flight
+func (v *Vertex) VoteToHalt() {
+	v.IsActive = false
 }
 
-func (v *Vertex) ComputePageRank() []Message {
-	totalFlow := 0.15
-
-	// update flow values
-	for _, message := range v.Messages {
-		flowValue := message.Value.(float64) // cast to an int
-		if message.SourceVertexId == INITIALIZATION_VERTEX {
-			totalFlow += flowValue
-		} else {
-			v.PreviousValues[message.SourceVertexId] = flowValue
-		}
-	}
-
-	// calculate new value
-	for _, flowValue := range v.PreviousValues {
-		totalFlow += flowValue.(float64)
-	}
+// numericValuer is implemented by vertex values that are not numbers but
+// report one, like the parent of a vertex in a spanning forest
+type numericValuer interface {
+	NumericValue() (float64, bool)
+}
 
-	// update neighbors at next step if the change is large enough
-	result := make([]Message, 0)
-	if math.Abs(totalFlow-v.CurrentValue.(float64)) > EPSILON {
-		for _, neighborVertexId := range v.Neighbors {
-			newMessage := Message{
-				SourceVertexId: v.Id,
-				DestVertexId:   neighborVertexId,
-				Value:          totalFlow / float64(len(v.Neighbors)),
-			}
-			result = append(result, newMessage)
-		}
-		v.CurrentValue = totalFlow
+// NumericValue converts the value of a vertex to a float64, and returns
+// false if the value is not a number
+func NumericValue(value interface{}) (float64, bool) {
+	switch numericValue := value.(type) {
+	case float64:
+		return numericValue, true
+	case numericValuer:
+		return numericValue.NumericValue()
+	case int:
+		return float64(numericValue), true
+	case uint64:
+		return float64(numericValue), true
+	default:
+		return 0, false
 	}
-	return result
 }
 
 func IsTargetVertex(
@@ -152,7 +155,7 @@ func isTargetSPVertex(vertexId uint64, vertices []uint64) bool {
 }
 
 func isSourceSPVertex(vertexId uint64, vertices []uint64) bool {
-	if len(vertices) < 2 {
+	if len(vertices) < 1 {
 		return false
 	}
 	return vertexId == vertices[0]
@@ -0,0 +1,190 @@
+package bagel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	coordgRPC "project/bagel/proto/coord"
+	"strings"
+	"sync"
+)
+
+// VertexProgram is the algorithm a query runs on every vertex. A new
+// VertexProgram is created for each query, so implementations can keep
+// query-specific state such as the source vertex. Every vertex is active at
+// superstep 1
+type VertexProgram interface {
+	// Validate returns an error if the query cannot be run by the program
+	Validate() error
+	// InitialValue returns the value a vertex holds before superstep 1
+	InitialValue(v *Vertex) interface{}
+	// Compute updates the vertex from v.Messages and returns the messages
+	// to send to other vertices. It runs on every active vertex and on every
+	// vertex with messages, and calls v.VoteToHalt once the vertex has no
+	// more work until it receives a message
+	Compute(v *Vertex) []Message
+}
+
+// MessageInitializer is implemented by vertex programs that deliver messages
+// to some vertices at superstep 1, such as the source of a search
+type MessageInitializer interface {
+	// InitialMessages returns the messages delivered to a vertex at
+	// superstep 1
+	InitialMessages(v *Vertex) []Message
+}
+
+// TargetReporter is implemented by vertex programs whose result is the value
+// of a target vertex. It is not used for programs implementing ResultMerger
+type TargetReporter interface {
+	// Result returns the value reported to the coord for the vertex, and
+	// whether the vertex holds the query result
+	Result(v *Vertex) (interface{}, bool)
+}
+
+// ResultMerger is implemented by vertex programs whose result is computed
+// from every vertex of the graph rather than read from a single target vertex
+type ResultMerger interface {
+	// PartialResult returns a worker's share of the result
+	PartialResult(vertices map[uint64]*Vertex) interface{}
+	// MergeResults combines the partial results reported by every worker
+	MergeResults(partials []interface{}) interface{}
+}
+
+// ResultWriter is implemented by vertex programs whose output is too large to
+// be sent to the client, such as random walk corpora. Every worker writes the
+// output of its vertices to a local file once the query ends
+type ResultWriter interface {
+	WriteResults(vertices map[uint64]*Vertex, writer io.Writer) error
+}
+
+// EdgeReporter is implemented by vertex programs whose VertexValues queries
+// return edges of the graph, such as the edges of a spanning forest, rather
+// than the value of every vertex
+type EdgeReporter interface {
+	// ResultEdges returns the result edges reported for the vertex
+	ResultEdges(v *Vertex) []SpanningEdge
+}
+
+// QueryResultFiller is implemented by query results holding more than a
+// number, such as the size of a component along with its id. The coord sets
+// the fields of the QueryResult sent to the client from them, and sends
+// other results as their NumericValue
+type QueryResultFiller interface {
+	FillQueryResult(reply *coordgRPC.QueryResult)
+}
+
+// Combiner is implemented by vertex programs whose Compute only depends on a
+// combination of the messages sent to a vertex, such as their minimum. The
+// workers then merge the messages sent to the same vertex before sending
+// them, and again when they are received
+type Combiner interface {
+	// Combine merges two messages sent to the same vertex into one
+	Combine(a Message, b Message) Message
+}
+
+// AggregatorProgram is implemented by vertex programs that compute global
+// values across the vertices of every superstep. A vertex contributes to an
+// aggregator with Vertex.Aggregate, and the value over every vertex is
+// visible to all vertices at the next superstep through Vertex.Aggregated
+type AggregatorProgram interface {
+	// Aggregators maps the name of every aggregator to its operation: sum,
+	// min, max or count
+	Aggregators() map[string]string
+}
+
+// MasterComputer is implemented by vertex programs that coordinate their
+// supersteps from the coord, such as multi-phase algorithms. MasterCompute
+// runs on the coord after every superstep barrier, and can halt the query,
+// change its phase or broadcast values to every vertex
+type MasterComputer interface {
+	MasterCompute(master *MasterContext)
+}
+
+// PathFinder is implemented by vertex programs that set the Predecessor of
+// every vertex they reach, so the coord can walk back from the destination
+// to reconstruct the path the query found
+type PathFinder interface {
+	// PathEnds returns the source and destination vertices of the path
+	PathEnds() (uint64, uint64)
+}
+
+// targetReporterOf returns the TargetReporter of the program, unless its
+// result is merged from every vertex
+func targetReporterOf(program VertexProgram) (TargetReporter, bool) {
+	if _, isMerger := program.(ResultMerger); isMerger {
+		return nil, false
+	}
+	reporter, isReporter := program.(TargetReporter)
+	return reporter, isReporter
+}
+
+// VertexProgramFactory creates the VertexProgram that runs a query
+type VertexProgramFactory func(query Query) VertexProgram
+
+var (
+	vertexPrograms      = make(map[string]VertexProgramFactory)
+	vertexProgramsMutex sync.RWMutex
+)
+
+// RegisterVertexProgram makes an algorithm available to queries with the
+// given query type. It is meant to be called from the init function of the
+// file implementing the algorithm
+func RegisterVertexProgram(queryType string, factory VertexProgramFactory) {
+	vertexProgramsMutex.Lock()
+	defer vertexProgramsMutex.Unlock()
+
+	if _, exists := vertexPrograms[queryType]; exists {
+		panic(fmt.Sprintf("vertex program %v registered twice", queryType))
+	}
+	vertexPrograms[queryType] = factory
+}
+
+func getVertexProgramFactory(queryType string) (VertexProgramFactory, bool) {
+	vertexProgramsMutex.RLock()
+	defer vertexProgramsMutex.RUnlock()
+
+	factory, exists := vertexPrograms[queryType]
+	return factory, exists
+}
+
+// registeredQueryType returns the query type of the registered program named
+// like name, ignoring case and underscores, so that PAGE_RANK names the
+// PageRank program
+func registeredQueryType(name string) (string, bool) {
+	vertexProgramsMutex.RLock()
+	defer vertexProgramsMutex.RUnlock()
+
+	name = strings.ReplaceAll(name, "_", "")
+	for queryType := range vertexPrograms {
+		if strings.EqualFold(strings.ReplaceAll(queryType, "_", ""), name) {
+			return queryType, true
+		}
+	}
+	return "", false
+}
+
+// NewVertexProgram creates the registered program for the query type and
+// validates the query against it
+func NewVertexProgram(query Query) (VertexProgram, error) {
+	factory, exists := getVertexProgramFactory(query.QueryType)
+	if !exists {
+		return nil, errors.New("unknown query type")
+	}
+
+	if query.IsTopKQuery() && query.TopK == 0 {
+		return nil, errors.New("top k query must rank at least one vertex")
+	}
+
+	program := factory(query)
+	if err := program.Validate(); err != nil {
+		return nil, err
+	}
+	for name, op := range aggregatorsOf(program) {
+		if !isAggregateOp(op) {
+			return nil, fmt.Errorf(
+				"aggregator %v has unknown operation %v", name, op,
+			)
+		}
+	}
+	return program, nil
+}
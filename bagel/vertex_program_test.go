@@ -0,0 +1,347 @@
+package bagel
+
+import (
+	"io"
+	"log"
+	"math"
+	"os"
+	coordgRPC "project/bagel/proto/coord"
+	"project/database/mongodb"
+	"reflect"
+	"testing"
+)
+
+const maxTestSuperSteps = 100
+
+func TestNewVertexProgramUnknownQueryType(t *testing.T) {
+	_, err := NewVertexProgram(Query{QueryType: "unknown", Nodes: []uint64{1}})
+	if err == nil {
+		t.Errorf("created a vertex program for an unknown query type")
+	}
+}
+
+func TestQueryTypeFromProto(t *testing.T) {
+	// every query type of the gRPC API names a registered program
+	for value, name := range coordgRPC.QUERY_TYPE_name {
+		queryType := queryTypeFromProto(
+			&coordgRPC.Query{QueryType: coordgRPC.QUERY_TYPE(value)},
+		)
+		if _, exists := getVertexProgramFactory(queryType); !exists {
+			t.Errorf("query type %v has no vertex program", name)
+		}
+	}
+
+	queryType := queryTypeFromProto(
+		&coordgRPC.Query{
+			QueryType: coordgRPC.QUERY_TYPE_PAGE_RANK, Algorithm: testCountdown,
+		},
+	)
+	if queryType != testCountdown {
+		t.Errorf("expected the query's algorithm but got %v", queryType)
+	}
+}
+
+func TestNewVertexProgramValidatesQuery(t *testing.T) {
+	if _, err := NewVertexProgram(
+		Query{QueryType: PAGE_RANK, Nodes: []uint64{1, 2}},
+	); err == nil {
+		t.Errorf("pagerank query with two vertices should be invalid")
+	}
+	if _, err := NewVertexProgram(
+		Query{QueryType: SHORTEST_PATH, Nodes: []uint64{1}},
+	); err == nil {
+		t.Errorf("shortest path query with one vertex should be invalid")
+	}
+	if _, err := NewVertexProgram(
+		Query{QueryType: SHORTEST_PATH, Nodes: []uint64{1, 2}},
+	); err != nil {
+		t.Errorf("valid shortest path query returned error: %v", err)
+	}
+}
+
+func TestRunShortestPathQuery(t *testing.T) {
+	graph := map[uint64][]uint64{
+		1: {2, 3},
+		2: {4},
+		3: {4},
+		4: {5},
+		5: {},
+	}
+	_, result := runTestQuery(
+		t, Query{QueryType: SHORTEST_PATH, Nodes: []uint64{1, 5}}, graph,
+	)
+	if result != 3 {
+		t.Errorf("expected shortest path of 3 but got %v", result)
+	}
+}
+
+func TestShortestPathPredecessors(t *testing.T) {
+	graph := map[uint64][]uint64{
+		1: {2, 3},
+		2: {4},
+		3: {5},
+		4: {5},
+		5: {},
+		6: {1},
+	}
+	w, _ := runTestQuery(
+		t, Query{QueryType: SHORTEST_PATH, Nodes: []uint64{1, 5}}, graph,
+	)
+
+	expectedPredecessors := map[uint64]uint64{2: 1, 3: 1, 4: 2, 5: 3}
+	for vertexId, expected := range expectedPredecessors {
+		var result PredecessorResult
+		w.GetPredecessor(PredecessorRequest{VertexId: vertexId}, &result)
+		if !result.HasPredecessor || result.Predecessor != expected {
+			t.Errorf(
+				"expected predecessor %v for vertex %v but got %v",
+				expected, vertexId, result,
+			)
+		}
+	}
+
+	// neither the source nor unreachable vertices have a predecessor
+	for _, vertexId := range []uint64{1, 6} {
+		var result PredecessorResult
+		w.GetPredecessor(PredecessorRequest{VertexId: vertexId}, &result)
+		if result.HasPredecessor {
+			t.Errorf("vertex %v should not have a predecessor", vertexId)
+		}
+	}
+}
+
+func TestVertexValuesQueryValidation(t *testing.T) {
+	if _, err := NewVertexProgram(
+		Query{QueryType: PAGE_RANK, ResultMode: RESULT_VERTEX_VALUES},
+	); err != nil {
+		t.Errorf("pagerank vertex values query returned error: %v", err)
+	}
+	if _, err := NewVertexProgram(
+		Query{
+			QueryType: SHORTEST_PATH, Nodes: []uint64{1},
+			ResultMode: RESULT_VERTEX_VALUES,
+		},
+	); err != nil {
+		t.Errorf("single source shortest path query returned error: %v", err)
+	}
+}
+
+func TestGetVertexValues(t *testing.T) {
+	graph := map[uint64][]uint64{
+		1: {2, 3},
+		2: {4},
+		3: {4},
+		4: {},
+		5: {1},
+	}
+	w, _ := runTestQuery(
+		t, Query{
+			QueryType: SHORTEST_PATH, Nodes: []uint64{1},
+			ResultMode: RESULT_VERTEX_VALUES,
+		}, graph,
+	)
+
+	var result VertexValuesResult
+	w.GetVertexValues(VertexValuesRequest{}, &result)
+	expected := map[uint64]float64{1: 0, 2: 1, 3: 1, 4: 2, 5: math.MaxInt32}
+	if !reflect.DeepEqual(result.Values, expected) {
+		t.Errorf("expected vertex values %v but got %v", expected, result.Values)
+	}
+
+	w.GetVertexValues(VertexValuesRequest{Nodes: []uint64{4, 6}}, &result)
+	expected = map[uint64]float64{4: 2}
+	if !reflect.DeepEqual(result.Values, expected) {
+		t.Errorf("expected vertex values %v but got %v", expected, result.Values)
+	}
+}
+
+const (
+	testCountdown           = "TestCountdown"
+	testCountdownSuperSteps = 3
+)
+
+// countdownTestProgram keeps every vertex active without any messages for a
+// number of supersteps, counting them in the vertex value
+type countdownTestProgram struct{}
+
+func init() {
+	RegisterVertexProgram(
+		testCountdown, func(query Query) VertexProgram {
+			return &countdownTestProgram{}
+		},
+	)
+}
+
+func (p *countdownTestProgram) Validate() error {
+	return nil
+}
+
+func (p *countdownTestProgram) InitialValue(v *Vertex) interface{} {
+	return 0
+}
+
+func (p *countdownTestProgram) Compute(v *Vertex) []Message {
+	v.CurrentValue = v.CurrentValue.(int) + 1
+	if v.CurrentValue.(int) == testCountdownSuperSteps {
+		v.VoteToHalt()
+	}
+	return nil
+}
+
+func (p *countdownTestProgram) Result(v *Vertex) (interface{}, bool) {
+	return v.CurrentValue, v.Id == 1
+}
+
+func TestActiveVerticesRunWithoutMessages(t *testing.T) {
+	graph := map[uint64][]uint64{1: {2}, 2: {}}
+	w, result := runTestQuery(t, Query{QueryType: testCountdown}, graph)
+	if result != testCountdownSuperSteps {
+		t.Errorf(
+			"expected %v supersteps but got %v", testCountdownSuperSteps,
+			result,
+		)
+	}
+	if w.Vertices[2].CurrentValue != testCountdownSuperSteps {
+		t.Errorf("vertex 2 ran %v supersteps", w.Vertices[2].CurrentValue)
+	}
+}
+
+func TestMessagesReactivateHaltedVertices(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	w := newTestWorker(
+		t, Query{QueryType: SHORTEST_PATH, Nodes: []uint64{1, 3}},
+		[]mongodb.Vertex{
+			{ID: 1, Edges: []uint64{2}},
+			{ID: 2, Edges: []uint64{3}},
+			{ID: 3, Edges: []uint64{}},
+		},
+	)
+
+	var resp ProgressSuperStepResult
+	w.ComputeVertices(&ProgressSuperStep{SuperStepNum: 1}, &resp)
+	if w.Vertices[3].IsActive {
+		t.Errorf("vertex 3 did not vote to halt without a shorter path")
+	}
+	if !resp.IsActive || resp.NumSent != 1 || resp.NumReceived != 0 {
+		t.Errorf("unexpected superstep 1 result %+v", resp)
+	}
+
+	w.ComputeVertices(&ProgressSuperStep{SuperStepNum: 2}, &resp)
+	if resp.NumSent != 1 || resp.NumReceived != 1 {
+		t.Errorf("unexpected superstep 2 result %+v", resp)
+	}
+	w.ComputeVertices(&ProgressSuperStep{SuperStepNum: 3}, &resp)
+	if w.Vertices[3].CurrentValue != 2 {
+		t.Errorf(
+			"halted vertex 3 was not reactivated by its message: %v",
+			w.Vertices[3].CurrentValue,
+		)
+	}
+}
+
+func TestQueryNotDoneWithMessagesInFlight(t *testing.T) {
+	c := &Coord{messagesInFlight: 2}
+	if c.isQueryDone(superstepDone{allWorkersInactive: true}) {
+		t.Errorf("query is done with messages in flight")
+	}
+	c.messagesInFlight = 0
+	if !c.isQueryDone(superstepDone{allWorkersInactive: true}) {
+		t.Errorf("query is not done once every vertex halted")
+	}
+}
+
+// runTestQuery runs a query to completion on a single worker holding the
+// whole graph, given as a map of vertex ids to out-edges, and returns the
+// worker with the result the coord would compute. The in-edges are built
+// like they are when the graph is loaded
+func runTestQuery(
+	t *testing.T, query Query, graph map[uint64][]uint64,
+) (*Worker, interface{}) {
+	inEdges := make(map[uint64][]uint64)
+	for id, edges := range graph {
+		for _, edge := range edges {
+			inEdges[edge] = append(inEdges[edge], id)
+		}
+	}
+
+	vertices := make([]mongodb.Vertex, 0, len(graph))
+	for id, edges := range graph {
+		vertices = append(
+			vertices, mongodb.Vertex{ID: id, Edges: edges, InEdges: inEdges[id]},
+		)
+	}
+	return runTestQueryOnVertices(t, query, vertices)
+}
+
+// runTestQueryOnVertices is runTestQuery for a graph given as database
+// vertices, which can hold edge weights
+func runTestQueryOnVertices(
+	t *testing.T, query Query, vertices []mongodb.Vertex,
+) (*Worker, interface{}) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	w := newTestWorker(t, query, vertices)
+	c := &Coord{program: w.program}
+	logger := log.New(io.Discard, "", 0)
+
+	var aggregated map[string]float64
+	for superStepNum := uint64(1); superStepNum <= maxTestSuperSteps; superStepNum++ {
+		var resp ProgressSuperStepResult
+		err := w.ComputeVertices(
+			&ProgressSuperStep{
+				SuperStepNum: superStepNum, Aggregated: aggregated,
+				Master: c.masterState,
+			}, &resp,
+		)
+		if err != nil {
+			t.Fatalf("superstep %v failed: %v", superStepNum, err)
+		}
+		aggregated = reduceAggregates(
+			aggregatorsOf(w.program), []map[string]float64{resp.Aggregates},
+		)
+		result := superstepDone{
+			allWorkersInactive: !resp.IsActive, aggregated: aggregated,
+		}
+		c.messagesInFlight += resp.NumSent - resp.NumReceived
+		c.masterState = c.masterCompute(superStepNum, result, logger)
+		if !c.isQueryDone(result) || c.masterState.IsNewPhase {
+			continue
+		}
+
+		merger, isMerger := w.program.(ResultMerger)
+		if !isMerger {
+			return w, resp.CurrentValue
+		}
+		partials := make([]interface{}, 0, 1)
+		if resp.CurrentValue != nil {
+			partials = append(partials, resp.CurrentValue)
+		}
+		return w, merger.MergeResults(partials)
+	}
+
+	t.Fatalf("query did not finish in %v supersteps", maxTestSuperSteps)
+	return nil, nil
+}
+
+// newTestWorker creates a single worker holding every vertex of the graph,
+// ready to compute superstep 1 of the query
+func newTestWorker(
+	t *testing.T, query Query, vertices []mongodb.Vertex,
+) *Worker {
+	w := NewWorker(
+		WorkerConfig{
+			WorkerAddr:            "127.0.0.1:0",
+			WorkerListenAddr:      "127.0.0.1:0",
+			FCheckAckLocalAddress: "127.0.0.1:0",
+		},
+	)
+	w.NumWorkers = 1
+	if err := w.setQuery(query); err != nil {
+		t.Fatalf("invalid test query: %v", err)
+	}
+	w.initializeVertices(vertices)
+	return w
+}
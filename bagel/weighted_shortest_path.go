@@ -0,0 +1,93 @@
+package bagel
+
+import (
+	"errors"
+	"math"
+)
+
+// weightedShortestPathProgram finds the shortest path between two vertices
+// using the weight of each edge as its length. Vertices relax their distance
+// Bellman-Ford style, so edge weights must not be negative
+type weightedShortestPathProgram struct {
+	query Query
+}
+
+func init() {
+	RegisterVertexProgram(
+		WEIGHTED_SHORTEST_PATH, newWeightedShortestPathProgram,
+	)
+}
+
+func newWeightedShortestPathProgram(query Query) VertexProgram {
+	return &weightedShortestPathProgram{query: query}
+}
+
+func (p *weightedShortestPathProgram) Validate() error {
+	// the destination is optional if the query returns more than one vertex
+	if !p.query.IsTargetQuery() && len(p.query.Nodes) == 1 {
+		return nil
+	}
+	if len(p.query.Nodes) != 2 {
+		return errors.New("incorrect number of vertices in the query")
+	}
+	return nil
+}
+
+func (p *weightedShortestPathProgram) InitialValue(v *Vertex) interface{} {
+	return math.Inf(1)
+}
+
+func (p *weightedShortestPathProgram) InitialMessages(v *Vertex) []Message {
+	if !IsTargetVertex(v.Id, p.query.Nodes, SHORTEST_PATH_SOURCE) {
+		return nil
+	}
+	return []Message{{INITIALIZATION_VERTEX, v.Id, 0.0}}
+}
+
+func (p *weightedShortestPathProgram) Compute(v *Vertex) []Message {
+	result := make([]Message, 0)
+	shortestNewPath := math.Inf(1)
+	predecessor := uint64(INITIALIZATION_VERTEX)
+	for _, message := range v.Messages {
+		pathLength := message.Value.(float64)
+		v.PreviousValues[message.SourceVertexId] = pathLength
+		if pathLength < shortestNewPath {
+			shortestNewPath = pathLength
+			predecessor = message.SourceVertexId
+		}
+	}
+
+	if shortestNewPath < v.CurrentValue.(float64) {
+		v.CurrentValue = shortestNewPath
+		v.Predecessor = predecessor
+		for idx, neighborVertexId := range v.Neighbors {
+			newMessage := Message{
+				SourceVertexId: v.Id,
+				DestVertexId:   neighborVertexId,
+				Value:          shortestNewPath + v.EdgeWeight(idx),
+			}
+			result = append(result, newMessage)
+		}
+	}
+
+	// a vertex only has work to do when it is offered a shorter path
+	if len(result) == 0 {
+		v.VoteToHalt()
+	}
+	return result
+}
+
+func (p *weightedShortestPathProgram) Result(v *Vertex) (interface{}, bool) {
+	return v.CurrentValue, IsTargetVertex(
+		v.Id, p.query.Nodes, SHORTEST_PATH_DEST,
+	)
+}
+
+// Combine keeps the shortest path, which is the only one Compute can use
+func (p *weightedShortestPathProgram) Combine(a Message, b Message) Message {
+	return minMessage(a, b)
+}
+
+func (p *weightedShortestPathProgram) PathEnds() (uint64, uint64) {
+	return p.query.Nodes[0], p.query.Nodes[1]
+}
@@ -0,0 +1,55 @@
+package bagel
+
+import (
+	"math"
+	"project/database/mongodb"
+	"testing"
+)
+
+func TestWeightedShortestPathUsesEdgeWeights(t *testing.T) {
+	vertices := []mongodb.Vertex{
+		{ID: 1, Edges: []uint64{2, 3}, Weights: []float64{1, 10}},
+		{ID: 2, Edges: []uint64{3}, Weights: []float64{2.5}},
+		{ID: 3, Edges: []uint64{4}, Weights: []float64{0.5}},
+		{ID: 4, Edges: []uint64{}, Weights: []float64{}},
+	}
+	_, result := runTestQueryOnVertices(
+		t, Query{QueryType: WEIGHTED_SHORTEST_PATH, Nodes: []uint64{1, 4}},
+		vertices,
+	)
+	if result != 4.0 {
+		t.Errorf("expected weighted shortest path of 4 but got %v", result)
+	}
+}
+
+func TestWeightedShortestPathOnUnweightedGraph(t *testing.T) {
+	graph := map[uint64][]uint64{
+		1: {2, 3},
+		2: {4},
+		3: {4},
+		4: {5},
+		5: {},
+	}
+	_, result := runTestQuery(
+		t, Query{QueryType: WEIGHTED_SHORTEST_PATH, Nodes: []uint64{1, 5}},
+		graph,
+	)
+	if result != 3.0 {
+		t.Errorf("expected weighted shortest path of 3 but got %v", result)
+	}
+}
+
+func TestWeightedShortestPathUnreachable(t *testing.T) {
+	graph := map[uint64][]uint64{
+		1: {2},
+		2: {},
+		3: {1},
+	}
+	_, result := runTestQuery(
+		t, Query{QueryType: WEIGHTED_SHORTEST_PATH, Nodes: []uint64{1, 3}},
+		graph,
+	)
+	if !math.IsInf(result.(float64), 1) {
+		t.Errorf("expected unreachable vertex to be at +Inf but got %v", result)
+	}
+}
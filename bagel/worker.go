@@ -11,6 +11,7 @@ import (
 	"project/database/mongodb"
 	fchecker "project/fcheck"
 	"project/util"
+	"strings"
 	"sync"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -41,6 +42,7 @@ type Worker struct {
 	SuperStep       *SuperStep
 	NextSuperStep   *SuperStep
 	Query           Query
+	program         VertexProgram
 	Vertices        map[uint64]*Vertex
 	workerDirectory WorkerDirectory
 	workerCallBook  WorkerCallBook
@@ -55,13 +57,27 @@ type Worker struct {
 }
 
 type SuperStep struct {
-	Messages     map[uint64][]Message
-	Outgoing     map[uint32][]Message
-	IsCheckpoint bool
+	Messages map[uint64][]Message
+	Outgoing map[uint32][]Message
+	// mutations received for the superstep, which are applied before its
+	// vertices are computed, and the mutations requested at the superstep
+	Mutations         []Mutation
+	OutgoingMutations map[uint32][]Mutation
+	IsCheckpoint      bool
+	// number of received messages merged by the program's Combiner
+	NumCombined int
+	// number of messages received from the vertices, including the ones
+	// merged by the program's Combiner
+	NumReceived int
+	// aggregator values of the previous superstep
+	Aggregated map[string]float64
+	// state set by the program's MasterComputer for this superstep
+	Master MasterState
 }
 
 type BatchedMessages struct {
-	Batch []Message
+	Batch     []Message
+	Mutations []Mutation
 }
 
 func NewWorker(config WorkerConfig) *Worker {
@@ -100,9 +116,10 @@ func (w *Worker) startFCheckHBeat(workerId uint32, ackAddress string) string {
 
 func NewSuperStep() *SuperStep {
 	return &SuperStep{
-		Messages:     make(map[uint64][]Message),
-		Outgoing:     make(map[uint32][]Message),
-		IsCheckpoint: false,
+		Messages:          make(map[uint64][]Message),
+		Outgoing:          make(map[uint32][]Message),
+		OutgoingMutations: make(map[uint32][]Mutation),
+		IsCheckpoint:      false,
 	}
 }
 
@@ -127,32 +144,7 @@ func (w *Worker) retrieveVertices(
 		panic("getVerticesModulo failed")
 	}
 
-	w.workerMutex.Lock()
-	w.SuperStep = NewSuperStep()
-	w.NextSuperStep = NewSuperStep()
-
-	for _, v := range vertices {
-		var pianoVertex Vertex
-		if w.Query.QueryType == SHORTEST_PATH {
-			pianoVertex = *NewShortestPathVertex(
-				v.ID, v.Edges, math.MaxInt32,
-			)
-			if IsTargetVertex(v.ID, w.Query.Nodes, SHORTEST_PATH_SOURCE) {
-				initialMessage := Message{INITIALIZATION_VERTEX, v.ID, 0}
-				w.NextSuperStep.Messages[v.ID] = append(
-					w.NextSuperStep.Messages[v.ID], initialMessage,
-				)
-			}
-		} else {
-			pianoVertex = *NewPageRankVertex(v.ID, v.Edges)
-			initialMessage := Message{INITIALIZATION_VERTEX, v.ID, 0.85}
-			w.NextSuperStep.Messages[v.ID] = append(
-				w.NextSuperStep.Messages[v.ID], initialMessage,
-			)
-		}
-		w.Vertices[v.ID] = &pianoVertex
-	}
-	w.workerMutex.Unlock()
+	w.initializeVertices(vertices)
 
 	log.Printf(
 		"retrieveVertices: created partition of %v vertices for worker"+
@@ -164,14 +156,47 @@ func (w *Worker) retrieveVertices(
 	)
 }
 
+// initializeVertices sets the initial state of the partition's vertices
+// and queues the messages for superstep 1
+func (w *Worker) initializeVertices(vertices []mongodb.Vertex) {
+	w.workerMutex.Lock()
+	w.SuperStep = NewSuperStep()
+	w.NextSuperStep = NewSuperStep()
+
+	initializer, isInitializer := w.program.(MessageInitializer)
+	for _, v := range vertices {
+		pianoVertex := NewVertex(v.ID, v.Edges)
+		pianoVertex.InNeighbors = v.InEdges
+		pianoVertex.EdgeWeights = v.Weights
+		pianoVertex.CurrentValue = w.program.InitialValue(pianoVertex)
+		w.Vertices[v.ID] = pianoVertex
+		if !isInitializer {
+			continue
+		}
+		for _, initialMessage := range initializer.InitialMessages(pianoVertex) {
+			w.NextSuperStep.Messages[initialMessage.DestVertexId] = append(
+				w.NextSuperStep.Messages[initialMessage.DestVertexId],
+				initialMessage,
+			)
+		}
+	}
+	w.workerMutex.Unlock()
+}
+
 func (w *Worker) StartQuery(
 	startSuperStep StartSuperStep, reply *StartSuperStepResult,
 ) error {
 	log.Printf("StartQuery - Beginning start query")
 	w.NumWorkers = uint32(startSuperStep.NumWorkers)
 	w.workerDirectory = startSuperStep.WorkerDirectory
-	w.Query = startSuperStep.Query
 	w.LogicalId = startSuperStep.WorkerLogicalId
+	if err := w.setQuery(startSuperStep.Query); err != nil {
+		log.Printf(
+			"StartQuery: worker %v received invalid query: %v\n",
+			w.config.WorkerId, err,
+		)
+		return err
+	}
 
 	if !startSuperStep.IsReplica && w.Replica != (WorkerNode{}) {
 		replicaClient, err := util.DialRPC(startSuperStep.ReplicaAddr)
@@ -282,7 +307,13 @@ func (w *Worker) RevertToLastCheckpoint(
 	w.NumWorkers = uint32(req.NumWorkers)
 	w.UpdateWorkerCallBook(req.WorkerDirectory)
 	w.workerCallBook = make(WorkerCallBook)
-	w.Query = req.Query
+	if err := w.setQuery(req.Query); err != nil {
+		log.Printf(
+			"RevertToLastCheckpoint: worker %v received invalid query: %v\n",
+			w.config.WorkerId, err,
+		)
+		return err
+	}
 
 	log.Printf(
 		"running RevertToLastCheckpoint with superstep number: %v\n",
@@ -317,21 +348,37 @@ func (w *Worker) RevertToLastCheckpoint(
 		w.Vertices[k] = &Vertex{
 			Id:             v.Id,
 			Neighbors:      v.Neighbors,
+			InNeighbors:    v.InNeighbors,
+			EdgeWeights:    v.EdgeWeights,
 			PreviousValues: v.PreviousValues,
 			CurrentValue:   v.CurrentValue,
+			Predecessor:    v.Predecessor,
+			IsActive:       v.IsActive,
 		}
 	}
 
 	// set superstep state
 	w.workerMutex.Lock()
+	// the mutations queued for the checkpointed superstep are applied
+	// again when it is computed
 	w.NextSuperStep = &SuperStep{
-		Messages:     checkpoint.NextSuperStepState.Messages,
-		Outgoing:     checkpoint.NextSuperStepState.Outgoing,
-		IsCheckpoint: checkpoint.NextSuperStepState.IsCheckpoint,
+		Messages:          checkpoint.NextSuperStepState.Messages,
+		Outgoing:          checkpoint.NextSuperStepState.Outgoing,
+		Mutations:         checkpoint.NextSuperStepState.Mutations,
+		OutgoingMutations: make(map[uint32][]Mutation),
+		IsCheckpoint:      checkpoint.NextSuperStepState.IsCheckpoint,
+		NumReceived:       checkpoint.NextSuperStepState.NumReceived,
+		Aggregated:        checkpoint.NextSuperStepState.Aggregated,
+		Master:            checkpoint.NextSuperStepState.Master,
 	}
 	w.workerMutex.Unlock()
 
 	*reply = req
+	// the coord resumes the aggregators and the master state from the
+	// checkpointed superstep
+	reply.Aggregated = checkpoint.NextSuperStepState.Aggregated
+	reply.Master = checkpoint.NextSuperStepState.Master
+	reply.NumQueued = checkpoint.NextSuperStepState.NumReceived
 	return nil
 }
 
@@ -440,6 +487,14 @@ func (w *Worker) Start() error {
 func (w *Worker) EndQuery(req EndQuery, reply *EndQuery) error {
 	// TODO shut down resources
 	log.Printf("Worker %v in endQuery\n", w.LogicalId)
+	if writer, isWriter := w.program.(ResultWriter); isWriter {
+		if err := w.writeResults(writer); err != nil {
+			log.Printf(
+				"EndQuery: worker %v could not write results: %v\n",
+				w.config.WorkerId, err,
+			)
+		}
+	}
 	w.logger = nil
 	w.logFile.Close()
 
@@ -447,12 +502,44 @@ func (w *Worker) EndQuery(req EndQuery, reply *EndQuery) error {
 	return nil
 }
 
+// writeResults writes the output of the worker's vertices to a file named
+// after the query type and the worker, e.g. randomwalk1.txt
+func (w *Worker) writeResults(writer ResultWriter) error {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	file, err := os.Create(
+		fmt.Sprintf(
+			"%v%v.txt", strings.ToLower(w.Query.QueryType), w.config.WorkerId,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := writer.WriteResults(w.Vertices, file); err != nil {
+		return err
+	}
+	log.Printf(
+		"writeResults: worker %v wrote results to %v\n", w.config.WorkerId,
+		file.Name(),
+	)
+	return file.Sync()
+}
+
 func (w *Worker) ComputeVertices(
 	args *ProgressSuperStep, resp *ProgressSuperStepResult,
 ) error {
 	log.Printf("ComputeVertices: Beginning Superstep %v for worker: %v, args: %v\n", args.SuperStepNum, w, args)
 	//start := time.Now()
 
+	// the aggregator values and master state are checkpointed with the
+	// messages of superstep S
+	w.workerMutex.Lock()
+	w.NextSuperStep.Aggregated = args.Aggregated
+	w.NextSuperStep.Master = args.Master
+	w.workerMutex.Unlock()
+
 	// save the checkpoint before running superstep S
 	if args.IsCheckpoint && !args.IsRestart {
 		w.workerMutex.Lock()
@@ -481,35 +568,49 @@ func (w *Worker) ComputeVertices(
 		)
 	}
 
+	// the topology requested at the previous superstep is in place before
+	// any vertex is computed
+	w.workerMutex.Lock()
+	w.applyMutations(w.SuperStep.Mutations)
+	w.workerMutex.Unlock()
+
 	vertexMessages := make(VertexMessages)
 	hasActiveVertex := false
+	master := w.SuperStep.Master
+	superStep := newSuperStepContext(
+		args.SuperStepNum, w.program, w.SuperStep.Aggregated, master,
+	)
+	reporter, isReporter := targetReporterOf(w.program)
 	for _, vertex := range w.Vertices {
 		vertex.SetSuperStepInfo(w.SuperStep.Messages[vertex.Id])
-		if len(vertex.Messages) > 0 {
-			messages := vertex.Compute(w.Query.QueryType)
+		vertex.superStep = superStep
+		// a halted query only reports its results. Otherwise, messages
+		// reactivate the vertices that voted to halt, and every vertex is
+		// computed at the first superstep of a new phase
+		if !master.IsHalted && (vertex.IsActive || len(vertex.Messages) > 0 ||
+			master.IsNewPhase) {
+			messages := vertex.Run(w.program)
 			w.mapMessagesToWorkers(messages)
-			if vertex.IsActive {
-				hasActiveVertex = true
-			}
 
 			// add to vertex messages map
 			vertexMessages[vertex.Id] = messages
 		}
-
-		vertexType := PAGE_RANK
-		if w.Query.QueryType == SHORTEST_PATH {
-			vertexType = SHORTEST_PATH_DEST
+		if vertex.IsActive {
+			hasActiveVertex = true
 		}
 
-		// if the current vertex is the source vertex, capture its value
-		if IsTargetVertex(vertex.Id, w.Query.Nodes, vertexType) {
+		// if the current vertex holds the query result, capture its value
+		if !isReporter {
+			continue
+		}
+		if value, isResult := reporter.Result(vertex); isResult {
 			log.Printf(
 				"ComputeVertices: target vertex %v is on"+
 					" worker %v with value %v at superstep %v\n",
-				vertex.Id, w.config.WorkerId, vertex.CurrentValue,
+				vertex.Id, w.config.WorkerId, value,
 				args.SuperStepNum,
 			)
-			resp.CurrentValue = vertex.CurrentValue
+			resp.CurrentValue = value
 
 			//w.logger.Printf(
 			//	"Completed computation with result %v\n", resp.CurrentValue,
@@ -521,19 +622,49 @@ func (w *Worker) ComputeVertices(
 		"!!!!!Worker %v: vertex messages: %v\n", w.LogicalId, vertexMessages,
 	)
 
+	// mutations travel with the messages, including to the workers the
+	// vertices sent no message to
+	w.mapMutationsToWorkers(superStep.mutations)
+	for worker := range w.SuperStep.OutgoingMutations {
+		if _, exists := w.SuperStep.Outgoing[worker]; !exists {
+			w.SuperStep.Outgoing[worker] = nil
+		}
+	}
+
+	// merge the messages to the same vertex before they are sent
+	numCombined := 0
+	if combiner, isCombiner := w.program.(Combiner); isCombiner {
+		for worker, msgs := range w.SuperStep.Outgoing {
+			var numWorkerCombined int
+			w.SuperStep.Outgoing[worker], numWorkerCombined = combineMessages(
+				combiner, msgs,
+			)
+			numCombined += numWorkerCombined
+		}
+		w.logCombinedMessages(
+			args.SuperStepNum, numCombined, w.SuperStep.NumCombined,
+		)
+	}
+
+	// the coord counts the messages in flight from the messages delivered
+	// and received by every worker
+	numSent := 0
 	for worker, msgs := range w.SuperStep.Outgoing {
+		mutations := w.SuperStep.OutgoingMutations[worker]
 		if worker == w.LogicalId {
 			w.workerMutex.Lock()
 			for _, msg := range msgs {
-				w.NextSuperStep.Messages[msg.DestVertexId] = append(
-					w.NextSuperStep.Messages[msg.DestVertexId], msg,
-				)
+				w.queueMessage(msg)
 			}
+			w.NextSuperStep.Mutations = append(
+				w.NextSuperStep.Mutations, mutations...,
+			)
 			w.workerMutex.Unlock()
+			numSent += len(msgs)
 			continue
 		}
 
-		batch := BatchedMessages{Batch: msgs}
+		batch := BatchedMessages{Batch: msgs, Mutations: mutations}
 
 		if _, exists := w.workerCallBook[worker]; !exists {
 			var err error
@@ -560,6 +691,8 @@ func (w *Worker) ComputeVertices(
 					" to worker: %v\n",
 				w.config.WorkerId, worker,
 			)
+		} else {
+			numSent += len(msgs)
 		}
 		log.Printf(
 			"ComputeVertices: worker #%v sending %v messages\n",
@@ -570,7 +703,18 @@ func (w *Worker) ComputeVertices(
 
 	resp.SuperStepNum = args.SuperStepNum
 	resp.IsCheckpoint = args.IsCheckpoint
-	resp.IsActive = hasActiveVertex && (w.Query.QueryType != PAGE_RANK || args.SuperStepNum < MAX_ITERATIONS)
+	// the worker stays active until the mutations it requested are applied
+	resp.IsActive = !master.IsHalted &&
+		(hasActiveVertex || len(superStep.mutations) > 0)
+	resp.Aggregates = superStep.partials
+	resp.NumSent = numSent
+	resp.NumReceived = w.SuperStep.NumReceived
+
+	// programs with a graph-wide result report their share once the worker
+	// is done, the coord merges the shares of all workers
+	if merger, isMerger := w.program.(ResultMerger); isMerger && !resp.IsActive {
+		resp.CurrentValue = merger.PartialResult(w.Vertices)
+	}
 	resp.Messages = vertexMessages
 
 	//duration := time.Since(start)
@@ -587,13 +731,15 @@ func (w *Worker) PutBatchedMessages(
 ) error {
 	w.workerMutex.Lock()
 	for _, msg := range batch.Batch {
-		w.NextSuperStep.Messages[msg.DestVertexId] = append(
-			w.NextSuperStep.Messages[msg.DestVertexId], msg,
-		)
+		w.queueMessage(msg)
 	}
+	w.NextSuperStep.Mutations = append(
+		w.NextSuperStep.Mutations, batch.Mutations...,
+	)
 	log.Printf(
-		"PutBatchedMessages: worker %v received %v messages",
-		w.config.WorkerId, len(batch.Batch),
+		"PutBatchedMessages: worker %v received %v messages and %v"+
+			" mutations",
+		w.config.WorkerId, len(batch.Batch), len(batch.Mutations),
 	)
 	w.workerMutex.Unlock()
 
@@ -601,6 +747,51 @@ func (w *Worker) PutBatchedMessages(
 	return nil
 }
 
+// queueMessage adds a received message to the messages of the next superstep,
+// merging it with the message already queued for its vertex if the program
+// has a Combiner. The caller must hold workerMutex
+func (w *Worker) queueMessage(msg Message) {
+	w.NextSuperStep.NumReceived++
+	queued := w.NextSuperStep.Messages[msg.DestVertexId]
+	if combiner, isCombiner := w.program.(Combiner); isCombiner &&
+		len(queued) > 0 {
+		queued[0] = combiner.Combine(queued[0], msg)
+		w.NextSuperStep.NumCombined++
+		return
+	}
+	w.NextSuperStep.Messages[msg.DestVertexId] = append(queued, msg)
+}
+
+// logCombinedMessages reports the number of messages the program's Combiner
+// saved the worker from sending and computing at the superstep
+func (w *Worker) logCombinedMessages(
+	superStepNum uint64, numSent int, numReceived int,
+) {
+	log.Printf(
+		"ComputeVertices: worker %v combined %v outgoing and %v incoming"+
+			" messages at superstep %v\n",
+		w.config.WorkerId, numSent, numReceived, superStepNum,
+	)
+	if w.logger != nil {
+		w.logger.Printf(
+			"Combined %v outgoing and %v incoming messages at superstep %v\n",
+			numSent, numReceived, superStepNum,
+		)
+	}
+}
+
+// setQuery sets the query the worker is running along with the vertex
+// program that computes it
+func (w *Worker) setQuery(query Query) error {
+	program, err := NewVertexProgram(query)
+	if err != nil {
+		return err
+	}
+	w.Query = query
+	w.program = program
+	return nil
+}
+
 func (w *Worker) switchToNextSuperStep() error {
 	w.workerMutex.Lock()
 	w.SuperStep = w.NextSuperStep
@@ -613,17 +804,94 @@ func (w *Worker) mapMessagesToWorkers(msgs []Message) {
 	w.workerMutex.Lock()
 	for _, msg := range msgs {
 		log.Printf("worker %v message: %v\n", w.config.WorkerId, msg)
-		destWorker := util.GetFlooredModulo(
-			util.HashId(msg.DestVertexId), uint64(w.NumWorkers),
-		)
+		destWorker := workerIdForVertex(msg.DestVertexId, w.NumWorkers)
 		log.Printf("dst worker: %v\n", destWorker)
-		w.SuperStep.Outgoing[uint32(destWorker)] = append(
-			w.SuperStep.Outgoing[uint32(destWorker)], msg,
+		w.SuperStep.Outgoing[destWorker] = append(
+			w.SuperStep.Outgoing[destWorker], msg,
 		)
 	}
 	w.workerMutex.Unlock()
 }
 
+// workerIdForVertex returns the logical id of the worker whose partition
+// holds the vertex
+func workerIdForVertex(vertexId uint64, numWorkers uint32) uint32 {
+	return uint32(
+		util.GetFlooredModulo(util.HashId(vertexId), uint64(numWorkers)),
+	)
+}
+
+// GetPredecessor returns the predecessor of one of the worker's vertices,
+// which is used by the coord to reconstruct the path found by a query
+func (w *Worker) GetPredecessor(
+	req PredecessorRequest, reply *PredecessorResult,
+) error {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	reply.VertexId = req.VertexId
+	vertex, exists := w.Vertices[req.VertexId]
+	if !exists || vertex.Predecessor == INITIALIZATION_VERTEX {
+		return nil
+	}
+	reply.Predecessor = vertex.Predecessor
+	reply.HasPredecessor = true
+	return nil
+}
+
+// GetVertexValues returns the values of the worker's vertices in req.Nodes,
+// or of all of its vertices if req.Nodes is empty. Vertices whose value is
+// not a number are left out. The result edges of those vertices are returned
+// as well if the program is an EdgeReporter
+func (w *Worker) GetVertexValues(
+	req VertexValuesRequest, reply *VertexValuesResult,
+) error {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	reply.Values = make(map[uint64]float64)
+	reporter, isReporter := w.program.(EdgeReporter)
+	addValue := func(vertex *Vertex) {
+		if value, isNumeric := NumericValue(vertex.CurrentValue); isNumeric {
+			reply.Values[vertex.Id] = value
+		}
+		if isReporter {
+			reply.Edges = append(reply.Edges, reporter.ResultEdges(vertex)...)
+		}
+	}
+
+	if len(req.Nodes) == 0 {
+		for _, vertex := range w.Vertices {
+			addValue(vertex)
+		}
+		return nil
+	}
+	for _, vertexId := range req.Nodes {
+		if vertex, exists := w.Vertices[vertexId]; exists {
+			addValue(vertex)
+		}
+	}
+	return nil
+}
+
+// GetTopK returns the req.K vertices of the worker with the highest values.
+// Vertices whose value is not a number are not ranked
+func (w *Worker) GetTopK(req TopKRequest, reply *TopKResult) error {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	candidates := make([]RankedVertex, 0, len(w.Vertices))
+	for _, vertex := range w.Vertices {
+		if value, isNumeric := NumericValue(vertex.CurrentValue); isNumeric {
+			candidates = append(
+				candidates, RankedVertex{VertexId: vertex.Id, Score: value},
+			)
+		}
+	}
+	reply.Ranking = topK(candidates, int(req.K))
+	return nil
+}
+
 func (w *Worker) UpdateWorkerCallBook(newDirectory WorkerDirectory) {
 	for workerId, workerAddr := range newDirectory {
 		if w.workerDirectory[workerId] != workerAddr {
@@ -11,12 +11,23 @@ const (
 	float64EqualityThreshold = 1e-8
 )
 
+// queries run on the test vertex, which is the source of the shortest path
+// and the target of the pagerank
+var (
+	shortestPathTestQuery = Query{
+		QueryType: SHORTEST_PATH, Nodes: []uint64{TEST_VERTEX_ID, 100},
+	}
+	pageRankTestQuery = Query{
+		QueryType: PAGE_RANK, Nodes: []uint64{TEST_VERTEX_ID},
+	}
+)
+
 func TestComputeShortestPathOneMessageshouldUpdate(t *testing.T) {
 	vertex := createNewTestVertex(10)
 	vertex.Messages = append(vertex.Messages, createTestMessage(2, 3))
 	vertex.Neighbors = append(vertex.Neighbors, 5, 6)
 
-	result := vertex.Compute(SHORTEST_PATH)
+	result := vertex.Run(newTestProgram(t, shortestPathTestQuery))
 	if vertex.CurrentValue != 3 {
 		t.Errorf("vertex did not update shortest path value correctly")
 	}
@@ -35,7 +46,7 @@ func TestComputeShortestPathOneMessageNoUpdate(t *testing.T) {
 	vertex.Messages = append(vertex.Messages, createTestMessage(2, 100))
 	vertex.Neighbors = append(vertex.Neighbors, 5, 6)
 
-	result := vertex.Compute(SHORTEST_PATH)
+	result := vertex.Run(newTestProgram(t, shortestPathTestQuery))
 	if vertex.CurrentValue != 10 {
 		t.Errorf("vertex updated shortest path value when it should not")
 	}
@@ -57,7 +68,7 @@ func TestComputeShortestPathMultipleMessagesShouldUpdate(t *testing.T) {
 	)
 	vertex.Neighbors = append(vertex.Neighbors, 5, 6, 7)
 
-	result := vertex.Compute(SHORTEST_PATH)
+	result := vertex.Run(newTestProgram(t, shortestPathTestQuery))
 	if vertex.CurrentValue != 2 {
 		t.Errorf("vertex did not update shortest path value correctly")
 	}
@@ -82,7 +93,7 @@ func TestComputeShortestPathMultipleMessagesNoUpdate(t *testing.T) {
 	)
 	vertex.Neighbors = append(vertex.Neighbors, 5, 6, 7)
 
-	result := vertex.Compute(SHORTEST_PATH)
+	result := vertex.Run(newTestProgram(t, shortestPathTestQuery))
 	if vertex.CurrentValue != 10 {
 		t.Errorf("vertex updated shortest path value when it should not")
 	}
@@ -99,7 +110,7 @@ func TestComputePageRankOneMessageOneNeighbor(t *testing.T) {
 	vertex.Messages = append(vertex.Messages, createTestMessage(2, 0.5))
 	vertex.Neighbors = append(vertex.Neighbors, 5)
 
-	result := vertex.Compute(PAGE_RANK)
+	result := vertex.Run(newTestProgram(t, pageRankTestQuery))
 	if !almostEqual(vertex.CurrentValue.(float64), 0.65) {
 		t.Errorf("vertex did not update pagerank value correctly")
 	}
@@ -109,7 +120,7 @@ func TestComputePageRankOneMessageOneNeighbor(t *testing.T) {
 	if !vertex.IsActive {
 		t.Errorf("vertex did not update IsActive correctly")
 	}
-	assertMessageMatches(t, result[0], 5, 0.65)
+	assertMessageMatches(t, result[0], 5, 0.5525)
 }
 
 func TestComputePageRankTwoMessagesOneNeighbor(t *testing.T) {
@@ -121,7 +132,7 @@ func TestComputePageRankTwoMessagesOneNeighbor(t *testing.T) {
 	)
 	vertex.Neighbors = append(vertex.Neighbors, 5)
 
-	result := vertex.Compute(PAGE_RANK)
+	result := vertex.Run(newTestProgram(t, pageRankTestQuery))
 	if !almostEqual(vertex.CurrentValue.(float64), 1.4) {
 		t.Errorf("vertex did not update pagerank value correctly")
 	}
@@ -131,7 +142,7 @@ func TestComputePageRankTwoMessagesOneNeighbor(t *testing.T) {
 	if !vertex.IsActive {
 		t.Errorf("vertex did not update IsActive correctly")
 	}
-	assertMessageMatches(t, result[0], 5, 1.4)
+	assertMessageMatches(t, result[0], 5, 1.19)
 }
 
 func TestComputePageRankOneMessageTwoNeighbors(t *testing.T) {
@@ -139,7 +150,7 @@ func TestComputePageRankOneMessageTwoNeighbors(t *testing.T) {
 	vertex.Messages = append(vertex.Messages, createTestMessage(2, 0.55))
 	vertex.Neighbors = append(vertex.Neighbors, 5, 6)
 
-	result := vertex.Compute(PAGE_RANK)
+	result := vertex.Run(newTestProgram(t, pageRankTestQuery))
 	if !almostEqual(vertex.CurrentValue.(float64), 0.70) {
 		t.Errorf("vertex did not update pagerank value correctly")
 	}
@@ -149,8 +160,8 @@ func TestComputePageRankOneMessageTwoNeighbors(t *testing.T) {
 	if !vertex.IsActive {
 		t.Errorf("vertex did not update IsActive correctly")
 	}
-	assertMessageMatches(t, result[0], 5, 0.35)
-	assertMessageMatches(t, result[1], 6, 0.35)
+	assertMessageMatches(t, result[0], 5, 0.2975)
+	assertMessageMatches(t, result[1], 6, 0.2975)
 }
 
 func TestComputePageRankManyMessagesManyNeighbors(t *testing.T) {
@@ -163,7 +174,7 @@ func TestComputePageRankManyMessagesManyNeighbors(t *testing.T) {
 	)
 	vertex.Neighbors = append(vertex.Neighbors, 5, 6, 7, 8, 9)
 
-	result := vertex.Compute(PAGE_RANK)
+	result := vertex.Run(newTestProgram(t, pageRankTestQuery))
 	if !almostEqual(vertex.CurrentValue.(float64), 2.5) {
 		t.Errorf("vertex did not update pagerank value correctly")
 	}
@@ -173,11 +184,11 @@ func TestComputePageRankManyMessagesManyNeighbors(t *testing.T) {
 	if !vertex.IsActive {
 		t.Errorf("vertex did not update IsActive correctly")
 	}
-	assertMessageMatches(t, result[0], 5, 0.50)
-	assertMessageMatches(t, result[1], 6, 0.50)
-	assertMessageMatches(t, result[2], 7, 0.50)
-	assertMessageMatches(t, result[3], 8, 0.50)
-	assertMessageMatches(t, result[4], 9, 0.50)
+	assertMessageMatches(t, result[0], 5, 0.425)
+	assertMessageMatches(t, result[1], 6, 0.425)
+	assertMessageMatches(t, result[2], 7, 0.425)
+	assertMessageMatches(t, result[3], 8, 0.425)
+	assertMessageMatches(t, result[4], 9, 0.425)
 }
 
 func TestComputePageRankNoResendIfWithinTolerance(t *testing.T) {
@@ -190,7 +201,7 @@ func TestComputePageRankNoResendIfWithinTolerance(t *testing.T) {
 	)
 	vertex.Neighbors = append(vertex.Neighbors, 5, 6, 7, 8, 9)
 
-	result := vertex.Compute(PAGE_RANK)
+	result := vertex.Run(newTestProgram(t, pageRankTestQuery))
 	if !almostEqual(vertex.CurrentValue.(float64), 2.5) {
 		t.Errorf("vertex did not update pagerank value correctly")
 	}
@@ -200,14 +211,14 @@ func TestComputePageRankNoResendIfWithinTolerance(t *testing.T) {
 	if !vertex.IsActive {
 		t.Errorf("vertex did not update IsActive correctly")
 	}
-	assertMessageMatches(t, result[0], 5, 0.50)
-	assertMessageMatches(t, result[1], 6, 0.50)
-	assertMessageMatches(t, result[2], 7, 0.50)
-	assertMessageMatches(t, result[3], 8, 0.50)
-	assertMessageMatches(t, result[4], 9, 0.50)
+	assertMessageMatches(t, result[0], 5, 0.425)
+	assertMessageMatches(t, result[1], 6, 0.425)
+	assertMessageMatches(t, result[2], 7, 0.425)
+	assertMessageMatches(t, result[3], 8, 0.425)
+	assertMessageMatches(t, result[4], 9, 0.425)
 
 	vertex.Messages[0].Value = vertex.Messages[0].Value.(float64) + EPSILON/2 // hope the change is < EPSILON
-	result = vertex.Compute(PAGE_RANK)
+	result = vertex.Run(newTestProgram(t, pageRankTestQuery))
 	if !almostEqual(vertex.CurrentValue.(float64), 2.5) {
 		t.Errorf("vertex updated pagerank value when it should not")
 	}
@@ -229,7 +240,7 @@ func TestComputePageRankOnlyRecomputeNewMessages(t *testing.T) {
 	)
 	vertex.Neighbors = append(vertex.Neighbors, 5, 6, 7, 8, 9)
 
-	result := vertex.Compute(PAGE_RANK)
+	result := vertex.Run(newTestProgram(t, pageRankTestQuery))
 	if !almostEqual(vertex.CurrentValue.(float64), 2.5) {
 		t.Errorf("vertex did not update pagerank value correctly")
 	}
@@ -239,16 +250,16 @@ func TestComputePageRankOnlyRecomputeNewMessages(t *testing.T) {
 	if !vertex.IsActive {
 		t.Errorf("vertex did not update IsActive correctly")
 	}
-	assertMessageMatches(t, result[0], 5, 0.50)
-	assertMessageMatches(t, result[1], 6, 0.50)
-	assertMessageMatches(t, result[2], 7, 0.50)
-	assertMessageMatches(t, result[3], 8, 0.50)
-	assertMessageMatches(t, result[4], 9, 0.50)
+	assertMessageMatches(t, result[0], 5, 0.425)
+	assertMessageMatches(t, result[1], 6, 0.425)
+	assertMessageMatches(t, result[2], 7, 0.425)
+	assertMessageMatches(t, result[3], 8, 0.425)
+	assertMessageMatches(t, result[4], 9, 0.425)
 
 	vertex.Messages = make([]Message, 1)
 	vertex.Messages[0] = createTestMessage(3, 0.8)
 
-	result = vertex.Compute(PAGE_RANK)
+	result = vertex.Run(newTestProgram(t, pageRankTestQuery))
 	if !almostEqual(vertex.CurrentValue.(float64), 3.0) {
 		t.Errorf("vertex did not update pagerank value correctly")
 	}
@@ -258,14 +269,23 @@ func TestComputePageRankOnlyRecomputeNewMessages(t *testing.T) {
 	if !vertex.IsActive {
 		t.Errorf("vertex did not update IsActive correctly")
 	}
-	assertMessageMatches(t, result[0], 5, 0.60)
-	assertMessageMatches(t, result[1], 6, 0.60)
-	assertMessageMatches(t, result[2], 7, 0.60)
-	assertMessageMatches(t, result[3], 8, 0.60)
-	assertMessageMatches(t, result[4], 9, 0.60)
+	assertMessageMatches(t, result[0], 5, 0.51)
+	assertMessageMatches(t, result[1], 6, 0.51)
+	assertMessageMatches(t, result[2], 7, 0.51)
+	assertMessageMatches(t, result[3], 8, 0.51)
+	assertMessageMatches(t, result[4], 9, 0.51)
 
 }
 
+// newTestProgram creates the program running the query, which must be valid
+func newTestProgram(t *testing.T, query Query) VertexProgram {
+	program, err := NewVertexProgram(query)
+	if err != nil {
+		t.Fatalf("test query %v is invalid: %v", query, err)
+	}
+	return program
+}
+
 func createNewTestVertex(initialVal interface{}) Vertex {
 	vertex := Vertex{
 		Id:             TEST_VERTEX_ID,
@@ -68,7 +68,7 @@ const CreateQuery = () => {
     queryClient.startQuery(query, null, (err, response) => {
       if (err) return console.log('startQuery err: ', err);
       console.log('startQuery response: ', response.toObject());
-      setQueryResult(response.toObject().result);
+      setQueryResult(response.toObject());
     });
   };
 
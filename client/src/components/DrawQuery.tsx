@@ -1,6 +1,7 @@
 import React, { useEffect, useState } from 'react';
 
 import { useQueryClient } from '../contexts/useQueryClient';
+import { useQueryResult } from '../contexts/useQueryResult';
 import { FetchGraphRequest } from '../proto/coord_pb';
 import * as Viva from 'vivagraphjs';
 
@@ -8,14 +9,20 @@ type Graph = {
   [workerId: number]: number[];
 };
 
+const PATH_COLOR = '#FF0000';
+
 const DrawQuery = () => {
   const { queryClient } = useQueryClient();
+  const { queryResult } = useQueryResult();
   const [graph, setGraph] = useState<Graph>({});
   const [renderer, setRenderer] = useState<any>(null);
 
+  // vertices from source to destination of a shortest path query
+  const path: number[] = queryResult?.pathList ?? [];
+
   useEffect(() => {
     drawInitialGraph();
-  }, [graph]);
+  }, [graph, queryResult]);
 
   // const fetchGraphRequest = new FetchGraphRequest();
   // queryClient.fetchGraph(fetchGraphRequest, null, (err, response) => {
@@ -52,6 +59,13 @@ const DrawQuery = () => {
       }
     }
 
+    path.forEach((vertexId, idx) => {
+      vivaGraph.addNode(vertexId, { color: PATH_COLOR });
+      if (idx > 0) {
+        vivaGraph.addLink(path[idx - 1], vertexId);
+      }
+    });
+
     const graphics = Viva.Graph.View.svgGraphics();
     graphics
       .node((node: { id: number; data: { color: string } }) => {
@@ -72,17 +86,26 @@ const DrawQuery = () => {
         nodeUI.attr('cx', pos.x).attr('cy', pos.y);
       });
 
-    const renderer = Viva.Graph.View.renderer(vivaGraph, {
+    if (renderer) {
+      renderer.dispose();
+    }
+
+    const newRenderer = Viva.Graph.View.renderer(vivaGraph, {
       graphics: graphics,
       container: document.getElementById('graph-container'),
     });
 
-    setRenderer(renderer);
+    setRenderer(newRenderer);
 
-    renderer.run();
+    newRenderer.run();
   };
 
-  return <div id='graph-container'></div>;
+  return (
+    <div>
+      {path.length > 0 && <div id='query-path'>Path: {path.join(' → ')}</div>}
+      <div id='graph-container'></div>
+    </div>
+  );
 };
 
 export default DrawQuery;
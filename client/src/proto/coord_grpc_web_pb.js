@@ -131,6 +131,62 @@ proto.coord.CoordPromiseClient.prototype.startQuery =
 };
 
 
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.coord.Query,
+ *   !proto.coord.VertexValuesResponse>}
+ */
+const methodDescriptor_Coord_StreamVertexValues = new grpc.web.MethodDescriptor(
+  '/coord.Coord/StreamVertexValues',
+  grpc.web.MethodType.SERVER_STREAMING,
+  proto.coord.Query,
+  proto.coord.VertexValuesResponse,
+  /**
+   * @param {!proto.coord.Query} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.coord.VertexValuesResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.coord.Query} request The request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.coord.VertexValuesResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.coord.CoordClient.prototype.streamVertexValues =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/coord.Coord/StreamVertexValues',
+      request,
+      metadata || {},
+      methodDescriptor_Coord_StreamVertexValues);
+};
+
+
+/**
+ * @param {!proto.coord.Query} request The request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.coord.VertexValuesResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.coord.CoordPromiseClient.prototype.streamVertexValues =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/coord.Coord/StreamVertexValues',
+      request,
+      metadata || {},
+      methodDescriptor_Coord_StreamVertexValues);
+};
+
+
 /**
  * @const
  * @type {!grpc.web.MethodDescriptor<
@@ -14,13 +14,19 @@ var global = Function('return this')();
 
 goog.exportSymbol('proto.coord.FetchGraphRequest', null, global);
 goog.exportSymbol('proto.coord.FetchGraphResponse', null, global);
+goog.exportSymbol('proto.coord.LinkCandidate', null, global);
 goog.exportSymbol('proto.coord.QUERY_TYPE', null, global);
 goog.exportSymbol('proto.coord.Query', null, global);
 goog.exportSymbol('proto.coord.QueryProgressRequest', null, global);
 goog.exportSymbol('proto.coord.QueryProgressResponse', null, global);
 goog.exportSymbol('proto.coord.QueryResult', null, global);
+goog.exportSymbol('proto.coord.RESULT_MODE', null, global);
+goog.exportSymbol('proto.coord.RankedVertex', null, global);
+goog.exportSymbol('proto.coord.ResultEdge', null, global);
 goog.exportSymbol('proto.coord.VertexMessage', null, global);
 goog.exportSymbol('proto.coord.VertexMessages', null, global);
+goog.exportSymbol('proto.coord.VertexValue', null, global);
+goog.exportSymbol('proto.coord.VertexValuesResponse', null, global);
 goog.exportSymbol('proto.coord.WorkerVertices', null, global);
 /**
  * Generated by JsPbCodeGenerator.
@@ -61,7 +67,14 @@ if (goog.DEBUG && !COMPILED) {
  * @constructor
  */
 proto.coord.QueryResult = function (opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+  jspb.Message.initialize(
+    this,
+    opt_data,
+    0,
+    -1,
+    proto.coord.QueryResult.repeatedFields_,
+    null
+  );
 };
 goog.inherits(proto.coord.QueryResult, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
@@ -71,6 +84,119 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.coord.QueryResult.displayName = 'proto.coord.QueryResult';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.coord.LinkCandidate = function (opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.coord.LinkCandidate, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.coord.LinkCandidate.displayName = 'proto.coord.LinkCandidate';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.coord.RankedVertex = function (opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.coord.RankedVertex, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.coord.RankedVertex.displayName = 'proto.coord.RankedVertex';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.coord.VertexValue = function (opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.coord.VertexValue, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.coord.VertexValue.displayName = 'proto.coord.VertexValue';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.coord.ResultEdge = function (opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.coord.ResultEdge, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.coord.ResultEdge.displayName = 'proto.coord.ResultEdge';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.coord.VertexValuesResponse = function (opt_data) {
+  jspb.Message.initialize(
+    this,
+    opt_data,
+    0,
+    -1,
+    proto.coord.VertexValuesResponse.repeatedFields_,
+    null
+  );
+};
+goog.inherits(proto.coord.VertexValuesResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.coord.VertexValuesResponse.displayName =
+    'proto.coord.VertexValuesResponse';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -240,7 +366,7 @@ if (goog.DEBUG && !COMPILED) {
  * @private {!Array<number>}
  * @const
  */
-proto.coord.Query.repeatedFields_ = [3];
+proto.coord.Query.repeatedFields_ = [3, 8];
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
   /**
@@ -277,6 +403,14 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
           (f = jspb.Message.getRepeatedField(msg, 3)) == null ? undefined : f,
         graph: jspb.Message.getFieldWithDefault(msg, 4, ''),
         tablename: jspb.Message.getFieldWithDefault(msg, 5, ''),
+        algorithm: jspb.Message.getFieldWithDefault(msg, 6, ''),
+        resultmode: jspb.Message.getFieldWithDefault(msg, 7, 0),
+        resultnodesList:
+          (f = jspb.Message.getRepeatedField(msg, 8)) == null ? undefined : f,
+        topk: jspb.Message.getFieldWithDefault(msg, 9, 0),
+        paramsMap: (f = msg.getParamsMap())
+          ? f.toObject(includeInstance, undefined)
+          : [],
       };
 
     if (includeInstance) {
@@ -327,9 +461,1624 @@ proto.coord.Query.deserializeBinaryFromReader = function (msg, reader) {
         var value = /** @type {string} */ (reader.readString());
         msg.setGraph(value);
         break;
-      case 5:
-        var value = /** @type {string} */ (reader.readString());
-        msg.setTablename(value);
+      case 5:
+        var value = /** @type {string} */ (reader.readString());
+        msg.setTablename(value);
+        break;
+      case 6:
+        var value = /** @type {string} */ (reader.readString());
+        msg.setAlgorithm(value);
+        break;
+      case 7:
+        var value = /** @type {!proto.coord.RESULT_MODE} */ (reader.readEnum());
+        msg.setResultmode(value);
+        break;
+      case 8:
+        var value = /** @type {!Array<number>} */ (reader.readPackedUint64());
+        msg.setResultnodesList(value);
+        break;
+      case 9:
+        var value = /** @type {number} */ (reader.readUint32());
+        msg.setTopk(value);
+        break;
+      case 10:
+        var value = msg.getParamsMap();
+        reader.readMessage(value, function (message, reader) {
+          jspb.Map.deserializeBinary(
+            message,
+            reader,
+            jspb.BinaryReader.prototype.readString,
+            jspb.BinaryReader.prototype.readDouble,
+            null,
+            '',
+            0.0
+          );
+        });
+        break;
+      default:
+        reader.skipField();
+        break;
+    }
+  }
+  return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.coord.Query.prototype.serializeBinary = function () {
+  var writer = new jspb.BinaryWriter();
+  proto.coord.Query.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.coord.Query} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.coord.Query.serializeBinaryToWriter = function (message, writer) {
+  var f = undefined;
+  f = message.getClientid();
+  if (f.length > 0) {
+    writer.writeString(1, f);
+  }
+  f = message.getQuerytype();
+  if (f !== 0.0) {
+    writer.writeEnum(2, f);
+  }
+  f = message.getNodesList();
+  if (f.length > 0) {
+    writer.writePackedUint64(3, f);
+  }
+  f = message.getGraph();
+  if (f.length > 0) {
+    writer.writeString(4, f);
+  }
+  f = message.getTablename();
+  if (f.length > 0) {
+    writer.writeString(5, f);
+  }
+  f = message.getAlgorithm();
+  if (f.length > 0) {
+    writer.writeString(6, f);
+  }
+  f = message.getResultmode();
+  if (f !== 0.0) {
+    writer.writeEnum(7, f);
+  }
+  f = message.getResultnodesList();
+  if (f.length > 0) {
+    writer.writePackedUint64(8, f);
+  }
+  f = message.getTopk();
+  if (f !== 0) {
+    writer.writeUint32(9, f);
+  }
+  f = message.getParamsMap(true);
+  if (f && f.getLength() > 0) {
+    f.serializeBinary(
+      10,
+      writer,
+      jspb.BinaryWriter.prototype.writeString,
+      jspb.BinaryWriter.prototype.writeDouble
+    );
+  }
+};
+
+/**
+ * optional string ClientId = 1;
+ * @return {string}
+ */
+proto.coord.Query.prototype.getClientid = function () {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ''));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.setClientid = function (value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+/**
+ * optional QUERY_TYPE QueryType = 2;
+ * @return {!proto.coord.QUERY_TYPE}
+ */
+proto.coord.Query.prototype.getQuerytype = function () {
+  return /** @type {!proto.coord.QUERY_TYPE} */ (
+    jspb.Message.getFieldWithDefault(this, 2, 0)
+  );
+};
+
+/**
+ * @param {!proto.coord.QUERY_TYPE} value
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.setQuerytype = function (value) {
+  return jspb.Message.setProto3EnumField(this, 2, value);
+};
+
+/**
+ * repeated uint64 Nodes = 3;
+ * @return {!Array<number>}
+ */
+proto.coord.Query.prototype.getNodesList = function () {
+  return /** @type {!Array<number>} */ (jspb.Message.getRepeatedField(this, 3));
+};
+
+/**
+ * @param {!Array<number>} value
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.setNodesList = function (value) {
+  return jspb.Message.setField(this, 3, value || []);
+};
+
+/**
+ * @param {number} value
+ * @param {number=} opt_index
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.addNodes = function (value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 3, value, opt_index);
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.clearNodesList = function () {
+  return this.setNodesList([]);
+};
+
+/**
+ * optional string Graph = 4;
+ * @return {string}
+ */
+proto.coord.Query.prototype.getGraph = function () {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ''));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.setGraph = function (value) {
+  return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+/**
+ * optional string TableName = 5;
+ * @return {string}
+ */
+proto.coord.Query.prototype.getTablename = function () {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ''));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.setTablename = function (value) {
+  return jspb.Message.setProto3StringField(this, 5, value);
+};
+
+/**
+ * optional string Algorithm = 6;
+ * @return {string}
+ */
+proto.coord.Query.prototype.getAlgorithm = function () {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ''));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.setAlgorithm = function (value) {
+  return jspb.Message.setProto3StringField(this, 6, value);
+};
+
+/**
+ * optional RESULT_MODE ResultMode = 7;
+ * @return {!proto.coord.RESULT_MODE}
+ */
+proto.coord.Query.prototype.getResultmode = function () {
+  return /** @type {!proto.coord.RESULT_MODE} */ (
+    jspb.Message.getFieldWithDefault(this, 7, 0)
+  );
+};
+
+/**
+ * @param {!proto.coord.RESULT_MODE} value
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.setResultmode = function (value) {
+  return jspb.Message.setProto3EnumField(this, 7, value);
+};
+
+/**
+ * repeated uint64 ResultNodes = 8;
+ * @return {!Array<number>}
+ */
+proto.coord.Query.prototype.getResultnodesList = function () {
+  return /** @type {!Array<number>} */ (jspb.Message.getRepeatedField(this, 8));
+};
+
+/**
+ * @param {!Array<number>} value
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.setResultnodesList = function (value) {
+  return jspb.Message.setField(this, 8, value || []);
+};
+
+/**
+ * @param {number} value
+ * @param {number=} opt_index
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.addResultnodes = function (value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 8, value, opt_index);
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.clearResultnodesList = function () {
+  return this.setResultnodesList([]);
+};
+
+/**
+ * optional uint32 TopK = 9;
+ * @return {number}
+ */
+proto.coord.Query.prototype.getTopk = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 9, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.setTopk = function (value) {
+  return jspb.Message.setProto3IntField(this, 9, value);
+};
+
+/**
+ * map<string, double> Params = 10;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,number>}
+ */
+proto.coord.Query.prototype.getParamsMap = function (opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,number>} */ (
+    jspb.Message.getMapField(this, 10, opt_noLazyCreate, null)
+  );
+};
+
+/**
+ * Clears values from the map. The map will be non-null.
+ * @return {!proto.coord.Query} returns this
+ */
+proto.coord.Query.prototype.clearParamsMap = function () {
+  this.getParamsMap().clear();
+  return this;
+};
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.coord.QueryResult.repeatedFields_ = [4, 5, 9, 12, 16];
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+  /**
+   * Creates an object representation of this proto.
+   * Field names that are reserved in JavaScript and will be renamed to pb_name.
+   * Optional fields that are not set will be set to undefined.
+   * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+   * For the list of reserved names please see:
+   *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+   * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+   *     JSPB instance for transitional soy proto support:
+   *     http://goto/soy-param-migration
+   * @return {!Object}
+   */
+  proto.coord.QueryResult.prototype.toObject = function (opt_includeInstance) {
+    return proto.coord.QueryResult.toObject(opt_includeInstance, this);
+  };
+
+  /**
+   * Static version of the {@see toObject} method.
+   * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+   *     the JSPB instance for transitional soy proto support:
+   *     http://goto/soy-param-migration
+   * @param {!proto.coord.QueryResult} msg The msg instance to transform.
+   * @return {!Object}
+   * @suppress {unusedLocalVariables} f is only used for nested messages
+   */
+  proto.coord.QueryResult.toObject = function (includeInstance, msg) {
+    var f,
+      obj = {
+        query:
+          (f = msg.getQuery()) &&
+          proto.coord.Query.toObject(includeInstance, f),
+        result: jspb.Message.getFloatingPointFieldWithDefault(msg, 2, 0.0),
+        error: jspb.Message.getFieldWithDefault(msg, 3, ''),
+        pathList:
+          (f = jspb.Message.getRepeatedField(msg, 4)) == null ? undefined : f,
+        rankingList: jspb.Message.toObjectList(
+          msg.getRankingList(),
+          proto.coord.RankedVertex.toObject,
+          includeInstance
+        ),
+        componentsize: jspb.Message.getFieldWithDefault(msg, 6, 0),
+        sizehistogramMap: (f = msg.getSizehistogramMap())
+          ? f.toObject(includeInstance, undefined)
+          : [],
+        hubscore: jspb.Message.getFloatingPointFieldWithDefault(msg, 8, 0.0),
+        hubrankingList: jspb.Message.toObjectList(
+          msg.getHubrankingList(),
+          proto.coord.RankedVertex.toObject,
+          includeInstance
+        ),
+        maxcoreness: jspb.Message.getFieldWithDefault(msg, 10, 0),
+        maxcoresize: jspb.Message.getFieldWithDefault(msg, 11, 0),
+        neighborhoodfunctionList:
+          (f = jspb.Message.getRepeatedFloatingPointField(msg, 12)) == null
+            ? undefined
+            : f,
+        averagedistance: jspb.Message.getFloatingPointFieldWithDefault(
+          msg,
+          13,
+          0.0
+        ),
+        numcolors: jspb.Message.getFieldWithDefault(msg, 14, 0),
+        numtrees: jspb.Message.getFieldWithDefault(msg, 15, 0),
+        linkcandidatesList: jspb.Message.toObjectList(
+          msg.getLinkcandidatesList(),
+          proto.coord.LinkCandidate.toObject,
+          includeInstance
+        ),
+        numsources: jspb.Message.getFieldWithDefault(msg, 17, 0),
+      };
+
+    if (includeInstance) {
+      obj.$jspbMessageInstance = msg;
+    }
+    return obj;
+  };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.coord.QueryResult}
+ */
+proto.coord.QueryResult.deserializeBinary = function (bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.coord.QueryResult();
+  return proto.coord.QueryResult.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.coord.QueryResult} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.coord.QueryResult}
+ */
+proto.coord.QueryResult.deserializeBinaryFromReader = function (msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+      case 1:
+        var value = new proto.coord.Query();
+        reader.readMessage(
+          value,
+          proto.coord.Query.deserializeBinaryFromReader
+        );
+        msg.setQuery(value);
+        break;
+      case 2:
+        var value = /** @type {number} */ (reader.readDouble());
+        msg.setResult(value);
+        break;
+      case 3:
+        var value = /** @type {string} */ (reader.readString());
+        msg.setError(value);
+        break;
+      case 4:
+        var value = /** @type {!Array<number>} */ (reader.readPackedUint64());
+        msg.setPathList(value);
+        break;
+      case 5:
+        var value = new proto.coord.RankedVertex();
+        reader.readMessage(
+          value,
+          proto.coord.RankedVertex.deserializeBinaryFromReader
+        );
+        msg.addRanking(value);
+        break;
+      case 6:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setComponentsize(value);
+        break;
+      case 7:
+        var value = msg.getSizehistogramMap();
+        reader.readMessage(value, function (message, reader) {
+          jspb.Map.deserializeBinary(
+            message,
+            reader,
+            jspb.BinaryReader.prototype.readUint64,
+            jspb.BinaryReader.prototype.readUint64,
+            null,
+            0,
+            0
+          );
+        });
+        break;
+      case 8:
+        var value = /** @type {number} */ (reader.readDouble());
+        msg.setHubscore(value);
+        break;
+      case 9:
+        var value = new proto.coord.RankedVertex();
+        reader.readMessage(
+          value,
+          proto.coord.RankedVertex.deserializeBinaryFromReader
+        );
+        msg.addHubranking(value);
+        break;
+      case 10:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setMaxcoreness(value);
+        break;
+      case 11:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setMaxcoresize(value);
+        break;
+      case 12:
+        var value = /** @type {!Array<number>} */ (reader.readPackedDouble());
+        msg.setNeighborhoodfunctionList(value);
+        break;
+      case 13:
+        var value = /** @type {number} */ (reader.readDouble());
+        msg.setAveragedistance(value);
+        break;
+      case 14:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setNumcolors(value);
+        break;
+      case 15:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setNumtrees(value);
+        break;
+      case 16:
+        var value = new proto.coord.LinkCandidate();
+        reader.readMessage(
+          value,
+          proto.coord.LinkCandidate.deserializeBinaryFromReader
+        );
+        msg.addLinkcandidates(value);
+        break;
+      case 17:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setNumsources(value);
+        break;
+      default:
+        reader.skipField();
+        break;
+    }
+  }
+  return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.coord.QueryResult.prototype.serializeBinary = function () {
+  var writer = new jspb.BinaryWriter();
+  proto.coord.QueryResult.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.coord.QueryResult} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.coord.QueryResult.serializeBinaryToWriter = function (message, writer) {
+  var f = undefined;
+  f = message.getQuery();
+  if (f != null) {
+    writer.writeMessage(1, f, proto.coord.Query.serializeBinaryToWriter);
+  }
+  f = message.getResult();
+  if (f !== 0.0) {
+    writer.writeDouble(2, f);
+  }
+  f = message.getError();
+  if (f.length > 0) {
+    writer.writeString(3, f);
+  }
+  f = message.getPathList();
+  if (f.length > 0) {
+    writer.writePackedUint64(4, f);
+  }
+  f = message.getRankingList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      5,
+      f,
+      proto.coord.RankedVertex.serializeBinaryToWriter
+    );
+  }
+  f = message.getComponentsize();
+  if (f !== 0) {
+    writer.writeUint64(6, f);
+  }
+  f = message.getSizehistogramMap(true);
+  if (f && f.getLength() > 0) {
+    f.serializeBinary(
+      7,
+      writer,
+      jspb.BinaryWriter.prototype.writeUint64,
+      jspb.BinaryWriter.prototype.writeUint64
+    );
+  }
+  f = message.getHubscore();
+  if (f !== 0.0) {
+    writer.writeDouble(8, f);
+  }
+  f = message.getHubrankingList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      9,
+      f,
+      proto.coord.RankedVertex.serializeBinaryToWriter
+    );
+  }
+  f = message.getMaxcoreness();
+  if (f !== 0) {
+    writer.writeUint64(10, f);
+  }
+  f = message.getMaxcoresize();
+  if (f !== 0) {
+    writer.writeUint64(11, f);
+  }
+  f = message.getNeighborhoodfunctionList();
+  if (f.length > 0) {
+    writer.writePackedDouble(12, f);
+  }
+  f = message.getAveragedistance();
+  if (f !== 0.0) {
+    writer.writeDouble(13, f);
+  }
+  f = message.getNumcolors();
+  if (f !== 0) {
+    writer.writeUint64(14, f);
+  }
+  f = message.getNumtrees();
+  if (f !== 0) {
+    writer.writeUint64(15, f);
+  }
+  f = message.getLinkcandidatesList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      16,
+      f,
+      proto.coord.LinkCandidate.serializeBinaryToWriter
+    );
+  }
+  f = message.getNumsources();
+  if (f !== 0) {
+    writer.writeUint64(17, f);
+  }
+};
+
+/**
+ * optional Query Query = 1;
+ * @return {?proto.coord.Query}
+ */
+proto.coord.QueryResult.prototype.getQuery = function () {
+  return /** @type{?proto.coord.Query} */ (
+    jspb.Message.getWrapperField(this, proto.coord.Query, 1)
+  );
+};
+
+/**
+ * @param {?proto.coord.Query|undefined} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setQuery = function (value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.clearQuery = function () {
+  return this.setQuery(undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.coord.QueryResult.prototype.hasQuery = function () {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+/**
+ * optional double Result = 2;
+ * @return {number}
+ */
+proto.coord.QueryResult.prototype.getResult = function () {
+  return /** @type {number} */ (
+    jspb.Message.getFloatingPointFieldWithDefault(this, 2, 0.0)
+  );
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setResult = function (value) {
+  return jspb.Message.setProto3FloatField(this, 2, value);
+};
+
+/**
+ * optional string Error = 3;
+ * @return {string}
+ */
+proto.coord.QueryResult.prototype.getError = function () {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ''));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setError = function (value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+/**
+ * repeated uint64 Path = 4;
+ * @return {!Array<number>}
+ */
+proto.coord.QueryResult.prototype.getPathList = function () {
+  return /** @type {!Array<number>} */ (jspb.Message.getRepeatedField(this, 4));
+};
+
+/**
+ * @param {!Array<number>} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setPathList = function (value) {
+  return jspb.Message.setField(this, 4, value || []);
+};
+
+/**
+ * @param {number} value
+ * @param {number=} opt_index
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.addPath = function (value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 4, value, opt_index);
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.clearPathList = function () {
+  return this.setPathList([]);
+};
+
+/**
+ * repeated RankedVertex Ranking = 5;
+ * @return {!Array<!proto.coord.RankedVertex>}
+ */
+proto.coord.QueryResult.prototype.getRankingList = function () {
+  return /** @type{!Array<!proto.coord.RankedVertex>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.coord.RankedVertex, 5)
+  );
+};
+
+/**
+ * @param {!Array<!proto.coord.RankedVertex>} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setRankingList = function (value) {
+  return jspb.Message.setRepeatedWrapperField(this, 5, value);
+};
+
+/**
+ * @param {!proto.coord.RankedVertex=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.coord.RankedVertex}
+ */
+proto.coord.QueryResult.prototype.addRanking = function (opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(
+    this,
+    5,
+    opt_value,
+    proto.coord.RankedVertex,
+    opt_index
+  );
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.clearRankingList = function () {
+  return this.setRankingList([]);
+};
+
+/**
+ * optional uint64 ComponentSize = 6;
+ * @return {number}
+ */
+proto.coord.QueryResult.prototype.getComponentsize = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setComponentsize = function (value) {
+  return jspb.Message.setProto3IntField(this, 6, value);
+};
+
+/**
+ * map<uint64, uint64> SizeHistogram = 7;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<number,number>}
+ */
+proto.coord.QueryResult.prototype.getSizehistogramMap = function (
+  opt_noLazyCreate
+) {
+  return /** @type {!jspb.Map<number,number>} */ (
+    jspb.Message.getMapField(this, 7, opt_noLazyCreate, null)
+  );
+};
+
+/**
+ * Clears values from the map. The map will be non-null.
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.clearSizehistogramMap = function () {
+  this.getSizehistogramMap().clear();
+  return this;
+};
+
+/**
+ * optional double HubScore = 8;
+ * @return {number}
+ */
+proto.coord.QueryResult.prototype.getHubscore = function () {
+  return /** @type {number} */ (
+    jspb.Message.getFloatingPointFieldWithDefault(this, 8, 0.0)
+  );
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setHubscore = function (value) {
+  return jspb.Message.setProto3FloatField(this, 8, value);
+};
+
+/**
+ * repeated RankedVertex HubRanking = 9;
+ * @return {!Array<!proto.coord.RankedVertex>}
+ */
+proto.coord.QueryResult.prototype.getHubrankingList = function () {
+  return /** @type{!Array<!proto.coord.RankedVertex>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.coord.RankedVertex, 9)
+  );
+};
+
+/**
+ * @param {!Array<!proto.coord.RankedVertex>} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setHubrankingList = function (value) {
+  return jspb.Message.setRepeatedWrapperField(this, 9, value);
+};
+
+/**
+ * @param {!proto.coord.RankedVertex=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.coord.RankedVertex}
+ */
+proto.coord.QueryResult.prototype.addHubranking = function (
+  opt_value,
+  opt_index
+) {
+  return jspb.Message.addToRepeatedWrapperField(
+    this,
+    9,
+    opt_value,
+    proto.coord.RankedVertex,
+    opt_index
+  );
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.clearHubrankingList = function () {
+  return this.setHubrankingList([]);
+};
+
+/**
+ * optional uint64 MaxCoreness = 10;
+ * @return {number}
+ */
+proto.coord.QueryResult.prototype.getMaxcoreness = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 10, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setMaxcoreness = function (value) {
+  return jspb.Message.setProto3IntField(this, 10, value);
+};
+
+/**
+ * optional uint64 MaxCoreSize = 11;
+ * @return {number}
+ */
+proto.coord.QueryResult.prototype.getMaxcoresize = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 11, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setMaxcoresize = function (value) {
+  return jspb.Message.setProto3IntField(this, 11, value);
+};
+
+/**
+ * repeated double NeighborhoodFunction = 12;
+ * @return {!Array<number>}
+ */
+proto.coord.QueryResult.prototype.getNeighborhoodfunctionList = function () {
+  return /** @type {!Array<number>} */ (
+    jspb.Message.getRepeatedFloatingPointField(this, 12)
+  );
+};
+
+/**
+ * @param {!Array<number>} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setNeighborhoodfunctionList = function (
+  value
+) {
+  return jspb.Message.setField(this, 12, value || []);
+};
+
+/**
+ * @param {number} value
+ * @param {number=} opt_index
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.addNeighborhoodfunction = function (
+  value,
+  opt_index
+) {
+  return jspb.Message.addToRepeatedField(this, 12, value, opt_index);
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.clearNeighborhoodfunctionList = function () {
+  return this.setNeighborhoodfunctionList([]);
+};
+
+/**
+ * optional double AverageDistance = 13;
+ * @return {number}
+ */
+proto.coord.QueryResult.prototype.getAveragedistance = function () {
+  return /** @type {number} */ (
+    jspb.Message.getFloatingPointFieldWithDefault(this, 13, 0.0)
+  );
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setAveragedistance = function (value) {
+  return jspb.Message.setProto3FloatField(this, 13, value);
+};
+
+/**
+ * optional uint64 NumColors = 14;
+ * @return {number}
+ */
+proto.coord.QueryResult.prototype.getNumcolors = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 14, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setNumcolors = function (value) {
+  return jspb.Message.setProto3IntField(this, 14, value);
+};
+
+/**
+ * optional uint64 NumTrees = 15;
+ * @return {number}
+ */
+proto.coord.QueryResult.prototype.getNumtrees = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 15, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setNumtrees = function (value) {
+  return jspb.Message.setProto3IntField(this, 15, value);
+};
+
+/**
+ * repeated LinkCandidate LinkCandidates = 16;
+ * @return {!Array<!proto.coord.LinkCandidate>}
+ */
+proto.coord.QueryResult.prototype.getLinkcandidatesList = function () {
+  return /** @type{!Array<!proto.coord.LinkCandidate>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.coord.LinkCandidate, 16)
+  );
+};
+
+/**
+ * @param {!Array<!proto.coord.LinkCandidate>} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setLinkcandidatesList = function (value) {
+  return jspb.Message.setRepeatedWrapperField(this, 16, value);
+};
+
+/**
+ * @param {!proto.coord.LinkCandidate=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.coord.LinkCandidate}
+ */
+proto.coord.QueryResult.prototype.addLinkcandidates = function (
+  opt_value,
+  opt_index
+) {
+  return jspb.Message.addToRepeatedWrapperField(
+    this,
+    16,
+    opt_value,
+    proto.coord.LinkCandidate,
+    opt_index
+  );
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.clearLinkcandidatesList = function () {
+  return this.setLinkcandidatesList([]);
+};
+
+/**
+ * optional uint64 NumSources = 17;
+ * @return {number}
+ */
+proto.coord.QueryResult.prototype.getNumsources = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 17, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.QueryResult} returns this
+ */
+proto.coord.QueryResult.prototype.setNumsources = function (value) {
+  return jspb.Message.setProto3IntField(this, 17, value);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+  /**
+   * Creates an object representation of this proto.
+   * Field names that are reserved in JavaScript and will be renamed to pb_name.
+   * Optional fields that are not set will be set to undefined.
+   * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+   * For the list of reserved names please see:
+   *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+   * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+   *     JSPB instance for transitional soy proto support:
+   *     http://goto/soy-param-migration
+   * @return {!Object}
+   */
+  proto.coord.LinkCandidate.prototype.toObject = function (
+    opt_includeInstance
+  ) {
+    return proto.coord.LinkCandidate.toObject(opt_includeInstance, this);
+  };
+
+  /**
+   * Static version of the {@see toObject} method.
+   * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+   *     the JSPB instance for transitional soy proto support:
+   *     http://goto/soy-param-migration
+   * @param {!proto.coord.LinkCandidate} msg The msg instance to transform.
+   * @return {!Object}
+   * @suppress {unusedLocalVariables} f is only used for nested messages
+   */
+  proto.coord.LinkCandidate.toObject = function (includeInstance, msg) {
+    var f,
+      obj = {
+        vertexid: jspb.Message.getFieldWithDefault(msg, 1, 0),
+        jaccard: jspb.Message.getFloatingPointFieldWithDefault(msg, 2, 0.0),
+        adamicadar: jspb.Message.getFloatingPointFieldWithDefault(msg, 3, 0.0),
+        commonneighbors: jspb.Message.getFieldWithDefault(msg, 4, 0),
+      };
+
+    if (includeInstance) {
+      obj.$jspbMessageInstance = msg;
+    }
+    return obj;
+  };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.coord.LinkCandidate}
+ */
+proto.coord.LinkCandidate.deserializeBinary = function (bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.coord.LinkCandidate();
+  return proto.coord.LinkCandidate.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.coord.LinkCandidate} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.coord.LinkCandidate}
+ */
+proto.coord.LinkCandidate.deserializeBinaryFromReader = function (msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+      case 1:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setVertexid(value);
+        break;
+      case 2:
+        var value = /** @type {number} */ (reader.readDouble());
+        msg.setJaccard(value);
+        break;
+      case 3:
+        var value = /** @type {number} */ (reader.readDouble());
+        msg.setAdamicadar(value);
+        break;
+      case 4:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setCommonneighbors(value);
+        break;
+      default:
+        reader.skipField();
+        break;
+    }
+  }
+  return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.coord.LinkCandidate.prototype.serializeBinary = function () {
+  var writer = new jspb.BinaryWriter();
+  proto.coord.LinkCandidate.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.coord.LinkCandidate} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.coord.LinkCandidate.serializeBinaryToWriter = function (message, writer) {
+  var f = undefined;
+  f = message.getVertexid();
+  if (f !== 0) {
+    writer.writeUint64(1, f);
+  }
+  f = message.getJaccard();
+  if (f !== 0.0) {
+    writer.writeDouble(2, f);
+  }
+  f = message.getAdamicadar();
+  if (f !== 0.0) {
+    writer.writeDouble(3, f);
+  }
+  f = message.getCommonneighbors();
+  if (f !== 0) {
+    writer.writeUint64(4, f);
+  }
+};
+
+/**
+ * optional uint64 VertexId = 1;
+ * @return {number}
+ */
+proto.coord.LinkCandidate.prototype.getVertexid = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.LinkCandidate} returns this
+ */
+proto.coord.LinkCandidate.prototype.setVertexid = function (value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+/**
+ * optional double Jaccard = 2;
+ * @return {number}
+ */
+proto.coord.LinkCandidate.prototype.getJaccard = function () {
+  return /** @type {number} */ (
+    jspb.Message.getFloatingPointFieldWithDefault(this, 2, 0.0)
+  );
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.LinkCandidate} returns this
+ */
+proto.coord.LinkCandidate.prototype.setJaccard = function (value) {
+  return jspb.Message.setProto3FloatField(this, 2, value);
+};
+
+/**
+ * optional double AdamicAdar = 3;
+ * @return {number}
+ */
+proto.coord.LinkCandidate.prototype.getAdamicadar = function () {
+  return /** @type {number} */ (
+    jspb.Message.getFloatingPointFieldWithDefault(this, 3, 0.0)
+  );
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.LinkCandidate} returns this
+ */
+proto.coord.LinkCandidate.prototype.setAdamicadar = function (value) {
+  return jspb.Message.setProto3FloatField(this, 3, value);
+};
+
+/**
+ * optional uint64 CommonNeighbors = 4;
+ * @return {number}
+ */
+proto.coord.LinkCandidate.prototype.getCommonneighbors = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.LinkCandidate} returns this
+ */
+proto.coord.LinkCandidate.prototype.setCommonneighbors = function (value) {
+  return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+  /**
+   * Creates an object representation of this proto.
+   * Field names that are reserved in JavaScript and will be renamed to pb_name.
+   * Optional fields that are not set will be set to undefined.
+   * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+   * For the list of reserved names please see:
+   *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+   * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+   *     JSPB instance for transitional soy proto support:
+   *     http://goto/soy-param-migration
+   * @return {!Object}
+   */
+  proto.coord.RankedVertex.prototype.toObject = function (opt_includeInstance) {
+    return proto.coord.RankedVertex.toObject(opt_includeInstance, this);
+  };
+
+  /**
+   * Static version of the {@see toObject} method.
+   * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+   *     the JSPB instance for transitional soy proto support:
+   *     http://goto/soy-param-migration
+   * @param {!proto.coord.RankedVertex} msg The msg instance to transform.
+   * @return {!Object}
+   * @suppress {unusedLocalVariables} f is only used for nested messages
+   */
+  proto.coord.RankedVertex.toObject = function (includeInstance, msg) {
+    var f,
+      obj = {
+        vertexid: jspb.Message.getFieldWithDefault(msg, 1, 0),
+        score: jspb.Message.getFloatingPointFieldWithDefault(msg, 2, 0.0),
+      };
+
+    if (includeInstance) {
+      obj.$jspbMessageInstance = msg;
+    }
+    return obj;
+  };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.coord.RankedVertex}
+ */
+proto.coord.RankedVertex.deserializeBinary = function (bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.coord.RankedVertex();
+  return proto.coord.RankedVertex.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.coord.RankedVertex} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.coord.RankedVertex}
+ */
+proto.coord.RankedVertex.deserializeBinaryFromReader = function (msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+      case 1:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setVertexid(value);
+        break;
+      case 2:
+        var value = /** @type {number} */ (reader.readDouble());
+        msg.setScore(value);
+        break;
+      default:
+        reader.skipField();
+        break;
+    }
+  }
+  return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.coord.RankedVertex.prototype.serializeBinary = function () {
+  var writer = new jspb.BinaryWriter();
+  proto.coord.RankedVertex.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.coord.RankedVertex} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.coord.RankedVertex.serializeBinaryToWriter = function (message, writer) {
+  var f = undefined;
+  f = message.getVertexid();
+  if (f !== 0) {
+    writer.writeUint64(1, f);
+  }
+  f = message.getScore();
+  if (f !== 0.0) {
+    writer.writeDouble(2, f);
+  }
+};
+
+/**
+ * optional uint64 VertexId = 1;
+ * @return {number}
+ */
+proto.coord.RankedVertex.prototype.getVertexid = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.RankedVertex} returns this
+ */
+proto.coord.RankedVertex.prototype.setVertexid = function (value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+/**
+ * optional double Score = 2;
+ * @return {number}
+ */
+proto.coord.RankedVertex.prototype.getScore = function () {
+  return /** @type {number} */ (
+    jspb.Message.getFloatingPointFieldWithDefault(this, 2, 0.0)
+  );
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.RankedVertex} returns this
+ */
+proto.coord.RankedVertex.prototype.setScore = function (value) {
+  return jspb.Message.setProto3FloatField(this, 2, value);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+  /**
+   * Creates an object representation of this proto.
+   * Field names that are reserved in JavaScript and will be renamed to pb_name.
+   * Optional fields that are not set will be set to undefined.
+   * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+   * For the list of reserved names please see:
+   *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+   * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+   *     JSPB instance for transitional soy proto support:
+   *     http://goto/soy-param-migration
+   * @return {!Object}
+   */
+  proto.coord.VertexValue.prototype.toObject = function (opt_includeInstance) {
+    return proto.coord.VertexValue.toObject(opt_includeInstance, this);
+  };
+
+  /**
+   * Static version of the {@see toObject} method.
+   * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+   *     the JSPB instance for transitional soy proto support:
+   *     http://goto/soy-param-migration
+   * @param {!proto.coord.VertexValue} msg The msg instance to transform.
+   * @return {!Object}
+   * @suppress {unusedLocalVariables} f is only used for nested messages
+   */
+  proto.coord.VertexValue.toObject = function (includeInstance, msg) {
+    var f,
+      obj = {
+        vertexid: jspb.Message.getFieldWithDefault(msg, 1, 0),
+        value: jspb.Message.getFloatingPointFieldWithDefault(msg, 2, 0.0),
+      };
+
+    if (includeInstance) {
+      obj.$jspbMessageInstance = msg;
+    }
+    return obj;
+  };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.coord.VertexValue}
+ */
+proto.coord.VertexValue.deserializeBinary = function (bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.coord.VertexValue();
+  return proto.coord.VertexValue.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.coord.VertexValue} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.coord.VertexValue}
+ */
+proto.coord.VertexValue.deserializeBinaryFromReader = function (msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+      case 1:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setVertexid(value);
+        break;
+      case 2:
+        var value = /** @type {number} */ (reader.readDouble());
+        msg.setValue(value);
+        break;
+      default:
+        reader.skipField();
+        break;
+    }
+  }
+  return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.coord.VertexValue.prototype.serializeBinary = function () {
+  var writer = new jspb.BinaryWriter();
+  proto.coord.VertexValue.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.coord.VertexValue} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.coord.VertexValue.serializeBinaryToWriter = function (message, writer) {
+  var f = undefined;
+  f = message.getVertexid();
+  if (f !== 0) {
+    writer.writeUint64(1, f);
+  }
+  f = message.getValue();
+  if (f !== 0.0) {
+    writer.writeDouble(2, f);
+  }
+};
+
+/**
+ * optional uint64 VertexId = 1;
+ * @return {number}
+ */
+proto.coord.VertexValue.prototype.getVertexid = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.VertexValue} returns this
+ */
+proto.coord.VertexValue.prototype.setVertexid = function (value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+/**
+ * optional double Value = 2;
+ * @return {number}
+ */
+proto.coord.VertexValue.prototype.getValue = function () {
+  return /** @type {number} */ (
+    jspb.Message.getFloatingPointFieldWithDefault(this, 2, 0.0)
+  );
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.coord.VertexValue} returns this
+ */
+proto.coord.VertexValue.prototype.setValue = function (value) {
+  return jspb.Message.setProto3FloatField(this, 2, value);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+  /**
+   * Creates an object representation of this proto.
+   * Field names that are reserved in JavaScript and will be renamed to pb_name.
+   * Optional fields that are not set will be set to undefined.
+   * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+   * For the list of reserved names please see:
+   *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+   * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+   *     JSPB instance for transitional soy proto support:
+   *     http://goto/soy-param-migration
+   * @return {!Object}
+   */
+  proto.coord.ResultEdge.prototype.toObject = function (opt_includeInstance) {
+    return proto.coord.ResultEdge.toObject(opt_includeInstance, this);
+  };
+
+  /**
+   * Static version of the {@see toObject} method.
+   * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+   *     the JSPB instance for transitional soy proto support:
+   *     http://goto/soy-param-migration
+   * @param {!proto.coord.ResultEdge} msg The msg instance to transform.
+   * @return {!Object}
+   * @suppress {unusedLocalVariables} f is only used for nested messages
+   */
+  proto.coord.ResultEdge.toObject = function (includeInstance, msg) {
+    var f,
+      obj = {
+        source: jspb.Message.getFieldWithDefault(msg, 1, 0),
+        dest: jspb.Message.getFieldWithDefault(msg, 2, 0),
+        weight: jspb.Message.getFloatingPointFieldWithDefault(msg, 3, 0.0),
+      };
+
+    if (includeInstance) {
+      obj.$jspbMessageInstance = msg;
+    }
+    return obj;
+  };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.coord.ResultEdge}
+ */
+proto.coord.ResultEdge.deserializeBinary = function (bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.coord.ResultEdge();
+  return proto.coord.ResultEdge.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.coord.ResultEdge} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.coord.ResultEdge}
+ */
+proto.coord.ResultEdge.deserializeBinaryFromReader = function (msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+      case 1:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setSource(value);
+        break;
+      case 2:
+        var value = /** @type {number} */ (reader.readUint64());
+        msg.setDest(value);
+        break;
+      case 3:
+        var value = /** @type {number} */ (reader.readDouble());
+        msg.setWeight(value);
         break;
       default:
         reader.skipField();
@@ -343,141 +2092,91 @@ proto.coord.Query.deserializeBinaryFromReader = function (msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.coord.Query.prototype.serializeBinary = function () {
+proto.coord.ResultEdge.prototype.serializeBinary = function () {
   var writer = new jspb.BinaryWriter();
-  proto.coord.Query.serializeBinaryToWriter(this, writer);
+  proto.coord.ResultEdge.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.coord.Query} message
+ * @param {!proto.coord.ResultEdge} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.coord.Query.serializeBinaryToWriter = function (message, writer) {
+proto.coord.ResultEdge.serializeBinaryToWriter = function (message, writer) {
   var f = undefined;
-  f = message.getClientid();
-  if (f.length > 0) {
-    writer.writeString(1, f);
-  }
-  f = message.getQuerytype();
-  if (f !== 0.0) {
-    writer.writeEnum(2, f);
-  }
-  f = message.getNodesList();
-  if (f.length > 0) {
-    writer.writePackedUint64(3, f);
+  f = message.getSource();
+  if (f !== 0) {
+    writer.writeUint64(1, f);
   }
-  f = message.getGraph();
-  if (f.length > 0) {
-    writer.writeString(4, f);
+  f = message.getDest();
+  if (f !== 0) {
+    writer.writeUint64(2, f);
   }
-  f = message.getTablename();
-  if (f.length > 0) {
-    writer.writeString(5, f);
+  f = message.getWeight();
+  if (f !== 0.0) {
+    writer.writeDouble(3, f);
   }
 };
 
 /**
- * optional string ClientId = 1;
- * @return {string}
- */
-proto.coord.Query.prototype.getClientid = function () {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ''));
-};
-
-/**
- * @param {string} value
- * @return {!proto.coord.Query} returns this
- */
-proto.coord.Query.prototype.setClientid = function (value) {
-  return jspb.Message.setProto3StringField(this, 1, value);
-};
-
-/**
- * optional QUERY_TYPE QueryType = 2;
- * @return {!proto.coord.QUERY_TYPE}
- */
-proto.coord.Query.prototype.getQuerytype = function () {
-  return /** @type {!proto.coord.QUERY_TYPE} */ (
-    jspb.Message.getFieldWithDefault(this, 2, 0)
-  );
-};
-
-/**
- * @param {!proto.coord.QUERY_TYPE} value
- * @return {!proto.coord.Query} returns this
- */
-proto.coord.Query.prototype.setQuerytype = function (value) {
-  return jspb.Message.setProto3EnumField(this, 2, value);
-};
-
-/**
- * repeated uint64 Nodes = 3;
- * @return {!Array<number>}
- */
-proto.coord.Query.prototype.getNodesList = function () {
-  return /** @type {!Array<number>} */ (jspb.Message.getRepeatedField(this, 3));
-};
-
-/**
- * @param {!Array<number>} value
- * @return {!proto.coord.Query} returns this
+ * optional uint64 Source = 1;
+ * @return {number}
  */
-proto.coord.Query.prototype.setNodesList = function (value) {
-  return jspb.Message.setField(this, 3, value || []);
+proto.coord.ResultEdge.prototype.getSource = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
 };
 
 /**
  * @param {number} value
- * @param {number=} opt_index
- * @return {!proto.coord.Query} returns this
+ * @return {!proto.coord.ResultEdge} returns this
  */
-proto.coord.Query.prototype.addNodes = function (value, opt_index) {
-  return jspb.Message.addToRepeatedField(this, 3, value, opt_index);
+proto.coord.ResultEdge.prototype.setSource = function (value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
 };
 
 /**
- * Clears the list making it empty but non-null.
- * @return {!proto.coord.Query} returns this
+ * optional uint64 Dest = 2;
+ * @return {number}
  */
-proto.coord.Query.prototype.clearNodesList = function () {
-  return this.setNodesList([]);
+proto.coord.ResultEdge.prototype.getDest = function () {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
 };
 
 /**
- * optional string Graph = 4;
- * @return {string}
+ * @param {number} value
+ * @return {!proto.coord.ResultEdge} returns this
  */
-proto.coord.Query.prototype.getGraph = function () {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ''));
+proto.coord.ResultEdge.prototype.setDest = function (value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
 };
 
 /**
- * @param {string} value
- * @return {!proto.coord.Query} returns this
+ * optional double Weight = 3;
+ * @return {number}
  */
-proto.coord.Query.prototype.setGraph = function (value) {
-  return jspb.Message.setProto3StringField(this, 4, value);
+proto.coord.ResultEdge.prototype.getWeight = function () {
+  return /** @type {number} */ (
+    jspb.Message.getFloatingPointFieldWithDefault(this, 3, 0.0)
+  );
 };
 
 /**
- * optional string TableName = 5;
- * @return {string}
+ * @param {number} value
+ * @return {!proto.coord.ResultEdge} returns this
  */
-proto.coord.Query.prototype.getTablename = function () {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ''));
+proto.coord.ResultEdge.prototype.setWeight = function (value) {
+  return jspb.Message.setProto3FloatField(this, 3, value);
 };
 
 /**
- * @param {string} value
- * @return {!proto.coord.Query} returns this
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
  */
-proto.coord.Query.prototype.setTablename = function (value) {
-  return jspb.Message.setProto3StringField(this, 5, value);
-};
+proto.coord.VertexValuesResponse.repeatedFields_ = [1, 3];
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
   /**
@@ -492,8 +2191,10 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
    *     http://goto/soy-param-migration
    * @return {!Object}
    */
-  proto.coord.QueryResult.prototype.toObject = function (opt_includeInstance) {
-    return proto.coord.QueryResult.toObject(opt_includeInstance, this);
+  proto.coord.VertexValuesResponse.prototype.toObject = function (
+    opt_includeInstance
+  ) {
+    return proto.coord.VertexValuesResponse.toObject(opt_includeInstance, this);
   };
 
   /**
@@ -501,18 +2202,24 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
    * @param {boolean|undefined} includeInstance Deprecated. Whether to include
    *     the JSPB instance for transitional soy proto support:
    *     http://goto/soy-param-migration
-   * @param {!proto.coord.QueryResult} msg The msg instance to transform.
+   * @param {!proto.coord.VertexValuesResponse} msg The msg instance to transform.
    * @return {!Object}
    * @suppress {unusedLocalVariables} f is only used for nested messages
    */
-  proto.coord.QueryResult.toObject = function (includeInstance, msg) {
+  proto.coord.VertexValuesResponse.toObject = function (includeInstance, msg) {
     var f,
       obj = {
-        query:
-          (f = msg.getQuery()) &&
-          proto.coord.Query.toObject(includeInstance, f),
-        result: jspb.Message.getFloatingPointFieldWithDefault(msg, 2, 0.0),
-        error: jspb.Message.getFieldWithDefault(msg, 3, ''),
+        vertexvaluesList: jspb.Message.toObjectList(
+          msg.getVertexvaluesList(),
+          proto.coord.VertexValue.toObject,
+          includeInstance
+        ),
+        error: jspb.Message.getFieldWithDefault(msg, 2, ''),
+        edgesList: jspb.Message.toObjectList(
+          msg.getEdgesList(),
+          proto.coord.ResultEdge.toObject,
+          includeInstance
+        ),
       };
 
     if (includeInstance) {
@@ -525,22 +2232,28 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.coord.QueryResult}
+ * @return {!proto.coord.VertexValuesResponse}
  */
-proto.coord.QueryResult.deserializeBinary = function (bytes) {
+proto.coord.VertexValuesResponse.deserializeBinary = function (bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.coord.QueryResult();
-  return proto.coord.QueryResult.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.coord.VertexValuesResponse();
+  return proto.coord.VertexValuesResponse.deserializeBinaryFromReader(
+    msg,
+    reader
+  );
 };
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.coord.QueryResult} msg The message object to deserialize into.
+ * @param {!proto.coord.VertexValuesResponse} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.coord.QueryResult}
+ * @return {!proto.coord.VertexValuesResponse}
  */
-proto.coord.QueryResult.deserializeBinaryFromReader = function (msg, reader) {
+proto.coord.VertexValuesResponse.deserializeBinaryFromReader = function (
+  msg,
+  reader
+) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -548,21 +2261,25 @@ proto.coord.QueryResult.deserializeBinaryFromReader = function (msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
       case 1:
-        var value = new proto.coord.Query();
+        var value = new proto.coord.VertexValue();
         reader.readMessage(
           value,
-          proto.coord.Query.deserializeBinaryFromReader
+          proto.coord.VertexValue.deserializeBinaryFromReader
         );
-        msg.setQuery(value);
+        msg.addVertexvalues(value);
         break;
       case 2:
-        var value = /** @type {number} */ (reader.readDouble());
-        msg.setResult(value);
-        break;
-      case 3:
         var value = /** @type {string} */ (reader.readString());
         msg.setError(value);
         break;
+      case 3:
+        var value = new proto.coord.ResultEdge();
+        reader.readMessage(
+          value,
+          proto.coord.ResultEdge.deserializeBinaryFromReader
+        );
+        msg.addEdges(value);
+        break;
       default:
         reader.skipField();
         break;
@@ -575,101 +2292,150 @@ proto.coord.QueryResult.deserializeBinaryFromReader = function (msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.coord.QueryResult.prototype.serializeBinary = function () {
+proto.coord.VertexValuesResponse.prototype.serializeBinary = function () {
   var writer = new jspb.BinaryWriter();
-  proto.coord.QueryResult.serializeBinaryToWriter(this, writer);
+  proto.coord.VertexValuesResponse.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.coord.QueryResult} message
+ * @param {!proto.coord.VertexValuesResponse} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.coord.QueryResult.serializeBinaryToWriter = function (message, writer) {
+proto.coord.VertexValuesResponse.serializeBinaryToWriter = function (
+  message,
+  writer
+) {
   var f = undefined;
-  f = message.getQuery();
-  if (f != null) {
-    writer.writeMessage(1, f, proto.coord.Query.serializeBinaryToWriter);
-  }
-  f = message.getResult();
-  if (f !== 0.0) {
-    writer.writeDouble(2, f);
+  f = message.getVertexvaluesList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      1,
+      f,
+      proto.coord.VertexValue.serializeBinaryToWriter
+    );
   }
   f = message.getError();
   if (f.length > 0) {
-    writer.writeString(3, f);
+    writer.writeString(2, f);
+  }
+  f = message.getEdgesList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      3,
+      f,
+      proto.coord.ResultEdge.serializeBinaryToWriter
+    );
   }
 };
 
 /**
- * optional Query Query = 1;
- * @return {?proto.coord.Query}
+ * repeated VertexValue VertexValues = 1;
+ * @return {!Array<!proto.coord.VertexValue>}
  */
-proto.coord.QueryResult.prototype.getQuery = function () {
-  return /** @type{?proto.coord.Query} */ (
-    jspb.Message.getWrapperField(this, proto.coord.Query, 1)
+proto.coord.VertexValuesResponse.prototype.getVertexvaluesList = function () {
+  return /** @type{!Array<!proto.coord.VertexValue>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.coord.VertexValue, 1)
   );
 };
 
 /**
- * @param {?proto.coord.Query|undefined} value
- * @return {!proto.coord.QueryResult} returns this
+ * @param {!Array<!proto.coord.VertexValue>} value
+ * @return {!proto.coord.VertexValuesResponse} returns this
  */
-proto.coord.QueryResult.prototype.setQuery = function (value) {
-  return jspb.Message.setWrapperField(this, 1, value);
+proto.coord.VertexValuesResponse.prototype.setVertexvaluesList = function (
+  value
+) {
+  return jspb.Message.setRepeatedWrapperField(this, 1, value);
 };
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.coord.QueryResult} returns this
+ * @param {!proto.coord.VertexValue=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.coord.VertexValue}
  */
-proto.coord.QueryResult.prototype.clearQuery = function () {
-  return this.setQuery(undefined);
+proto.coord.VertexValuesResponse.prototype.addVertexvalues = function (
+  opt_value,
+  opt_index
+) {
+  return jspb.Message.addToRepeatedWrapperField(
+    this,
+    1,
+    opt_value,
+    proto.coord.VertexValue,
+    opt_index
+  );
 };
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * Clears the list making it empty but non-null.
+ * @return {!proto.coord.VertexValuesResponse} returns this
  */
-proto.coord.QueryResult.prototype.hasQuery = function () {
-  return jspb.Message.getField(this, 1) != null;
+proto.coord.VertexValuesResponse.prototype.clearVertexvaluesList = function () {
+  return this.setVertexvaluesList([]);
 };
 
 /**
- * optional double Result = 2;
- * @return {number}
+ * optional string Error = 2;
+ * @return {string}
  */
-proto.coord.QueryResult.prototype.getResult = function () {
-  return /** @type {number} */ (
-    jspb.Message.getFloatingPointFieldWithDefault(this, 2, 0.0)
+proto.coord.VertexValuesResponse.prototype.getError = function () {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ''));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.coord.VertexValuesResponse} returns this
+ */
+proto.coord.VertexValuesResponse.prototype.setError = function (value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+/**
+ * repeated ResultEdge Edges = 3;
+ * @return {!Array<!proto.coord.ResultEdge>}
+ */
+proto.coord.VertexValuesResponse.prototype.getEdgesList = function () {
+  return /** @type{!Array<!proto.coord.ResultEdge>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.coord.ResultEdge, 3)
   );
 };
 
 /**
- * @param {number} value
- * @return {!proto.coord.QueryResult} returns this
+ * @param {!Array<!proto.coord.ResultEdge>} value
+ * @return {!proto.coord.VertexValuesResponse} returns this
  */
-proto.coord.QueryResult.prototype.setResult = function (value) {
-  return jspb.Message.setProto3FloatField(this, 2, value);
+proto.coord.VertexValuesResponse.prototype.setEdgesList = function (value) {
+  return jspb.Message.setRepeatedWrapperField(this, 3, value);
 };
 
 /**
- * optional string Error = 3;
- * @return {string}
+ * @param {!proto.coord.ResultEdge=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.coord.ResultEdge}
  */
-proto.coord.QueryResult.prototype.getError = function () {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ''));
+proto.coord.VertexValuesResponse.prototype.addEdges = function (
+  opt_value,
+  opt_index
+) {
+  return jspb.Message.addToRepeatedWrapperField(
+    this,
+    3,
+    opt_value,
+    proto.coord.ResultEdge,
+    opt_index
+  );
 };
 
 /**
- * @param {string} value
- * @return {!proto.coord.QueryResult} returns this
+ * Clears the list making it empty but non-null.
+ * @return {!proto.coord.VertexValuesResponse} returns this
  */
-proto.coord.QueryResult.prototype.setError = function (value) {
-  return jspb.Message.setProto3StringField(this, 3, value);
+proto.coord.VertexValuesResponse.prototype.clearEdgesList = function () {
+  return this.setEdgesList([]);
 };
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -1716,6 +3482,31 @@ proto.coord.FetchGraphResponse.prototype.clearWorkerverticesMap = function () {
 proto.coord.QUERY_TYPE = {
   PAGE_RANK: 0,
   SHORTEST_PATH: 1,
+  CONNECTED_COMPONENTS: 2,
+  WEIGHTED_SHORTEST_PATH: 3,
+  DEGREE: 4,
+  STRONGLY_CONNECTED_COMPONENTS: 5,
+  TRIANGLE_COUNT: 6,
+  LABEL_PROPAGATION: 7,
+  PERSONALIZED_PAGE_RANK: 8,
+  HITS: 9,
+  K_CORE: 10,
+  K_HOP_NEIGHBORHOOD: 11,
+  HYPER_ANF: 12,
+  GRAPH_COLORING: 13,
+  MINIMUM_SPANNING_FOREST: 14,
+  RANDOM_WALK: 15,
+  LINK_PREDICTION: 16,
+  BETWEENNESS: 17,
+};
+
+/**
+ * @enum {number}
+ */
+proto.coord.RESULT_MODE = {
+  TARGET: 0,
+  VERTEX_VALUES: 1,
+  TOP_K: 2,
 };
 
 // goog.object.extend(exports, proto.coord);
@@ -10,6 +10,9 @@ import (
 	"strings"
 )
 
+// topK is the command for ranking the vertices with the highest values
+const topK = "top"
+
 func main() {
 	// read config
 	var config bagel.ClientConfig
@@ -34,9 +37,11 @@ func main() {
 	invalidInput := false
 	var query bagel.Query
 
-	if len(os.Args) < 4 || len(os.Args) > 5 {
+	if len(os.Args) < 3 || len(os.Args) > 5 {
 		invalidInput = true
-	} else if strings.EqualFold(os.Args[1], bagel.PAGE_RANK) {
+	} else if strings.EqualFold(os.Args[1], bagel.PAGE_RANK) ||
+		strings.EqualFold(os.Args[1], bagel.DEGREE) ||
+		strings.EqualFold(os.Args[1], bagel.HITS) {
 		if len(os.Args) != 4 {
 			invalidInput = true
 		} else {
@@ -46,11 +51,17 @@ func main() {
 				invalidInput = true
 			} else {
 				query.QueryType = bagel.PAGE_RANK
+				if strings.EqualFold(os.Args[1], bagel.DEGREE) {
+					query.QueryType = bagel.DEGREE
+				} else if strings.EqualFold(os.Args[1], bagel.HITS) {
+					query.QueryType = bagel.HITS
+				}
 				query.Nodes = []uint64{uint64(v1)}
 				query.TableName = os.Args[3]
 			}
 		}
-	} else if strings.EqualFold(os.Args[1], bagel.SHORTEST_PATH) {
+	} else if strings.EqualFold(os.Args[1], bagel.SHORTEST_PATH) ||
+		strings.EqualFold(os.Args[1], bagel.WEIGHTED_SHORTEST_PATH) {
 		if len(os.Args) != 5 {
 			invalidInput = true
 		} else {
@@ -65,18 +76,221 @@ func main() {
 				invalidInput = true
 			} else {
 				query.QueryType = bagel.SHORTEST_PATH
+				if strings.EqualFold(os.Args[1], bagel.WEIGHTED_SHORTEST_PATH) {
+					query.QueryType = bagel.WEIGHTED_SHORTEST_PATH
+				}
 				query.Nodes = []uint64{uint64(v1), uint64(v2)}
 				query.TableName = os.Args[4]
 			}
 		}
+	} else if strings.EqualFold(os.Args[1], bagel.CONNECTED_COMPONENTS) ||
+		strings.EqualFold(os.Args[1], bagel.STRONGLY_CONNECTED_COMPONENTS) ||
+		strings.EqualFold(os.Args[1], bagel.TRIANGLE_COUNT) ||
+		strings.EqualFold(os.Args[1], bagel.LABEL_PROPAGATION) ||
+		strings.EqualFold(os.Args[1], bagel.K_CORE) ||
+		strings.EqualFold(os.Args[1], bagel.GRAPH_COLORING) {
+		queryType := bagel.CONNECTED_COMPONENTS
+		for _, graphQueryType := range []string{
+			bagel.STRONGLY_CONNECTED_COMPONENTS, bagel.TRIANGLE_COUNT,
+			bagel.LABEL_PROPAGATION, bagel.K_CORE, bagel.GRAPH_COLORING,
+		} {
+			if strings.EqualFold(os.Args[1], graphQueryType) {
+				queryType = graphQueryType
+			}
+		}
+		if len(os.Args) == 3 {
+			// summarize the components, communities, triangles, cores or
+			// colors of the graph
+			query.QueryType = queryType
+			query.TableName = os.Args[2]
+		} else if len(os.Args) == 4 {
+			v1, err := strconv.Atoi(os.Args[2])
+			if err != nil {
+				log.Println("Provided vertex could not be converted to integer")
+				invalidInput = true
+			} else {
+				query.QueryType = queryType
+				query.Nodes = []uint64{uint64(v1)}
+				query.TableName = os.Args[3]
+			}
+		} else {
+			invalidInput = true
+		}
+	} else if strings.EqualFold(os.Args[1], bagel.HYPER_ANF) ||
+		strings.EqualFold(os.Args[1], bagel.MINIMUM_SPANNING_FOREST) {
+		// estimate the effective diameter of the graph, or find its minimum
+		// spanning forest
+		if len(os.Args) != 3 {
+			invalidInput = true
+		} else {
+			query.QueryType = bagel.HYPER_ANF
+			if strings.EqualFold(os.Args[1], bagel.MINIMUM_SPANNING_FOREST) {
+				query.QueryType = bagel.MINIMUM_SPANNING_FOREST
+			}
+			query.TableName = os.Args[2]
+		}
+	} else if strings.EqualFold(os.Args[1], bagel.RANDOM_WALK) {
+		// generate walks of walkLength vertices from every vertex, biased by
+		// the node2vec p and q if given
+		numWalks, err := strconv.Atoi(os.Args[2])
+		if (len(os.Args) != 5 && len(os.Args) != 7) || err != nil {
+			invalidInput = true
+		} else if walkLength, err := strconv.Atoi(os.Args[3]); err != nil {
+			invalidInput = true
+		} else {
+			query.QueryType = bagel.RANDOM_WALK
+			query.Params = map[string]float64{
+				bagel.RANDOM_WALK_NUM_WALKS: float64(numWalks),
+				bagel.RANDOM_WALK_LENGTH:    float64(walkLength),
+			}
+			if len(os.Args) == 7 {
+				p, pErr := strconv.ParseFloat(os.Args[4], 64)
+				q, qErr := strconv.ParseFloat(os.Args[5], 64)
+				if pErr != nil || qErr != nil {
+					log.Println("Provided p and q could not be converted to numbers")
+					invalidInput = true
+				}
+				query.Params[bagel.RANDOM_WALK_RETURN] = p
+				query.Params[bagel.RANDOM_WALK_IN_OUT] = q
+			}
+			query.TableName = os.Args[len(os.Args)-1]
+		}
+	} else if strings.EqualFold(os.Args[1], bagel.LINK_PREDICTION) {
+		// rank the k best candidates for a link with the vertex, by jaccard
+		// unless another metric is given
+		k, err := strconv.Atoi(os.Args[2])
+		if (len(os.Args) != 5 && len(os.Args) != 6) || err != nil || k <= 0 {
+			invalidInput = true
+		} else if v1, err := strconv.Atoi(os.Args[3]); err != nil {
+			log.Println("Provided vertex could not be converted to integer")
+			invalidInput = true
+		} else {
+			query.QueryType = bagel.LINK_PREDICTION
+			query.ResultMode = bagel.RESULT_TOP_K
+			query.TopK = uint32(k)
+			query.Nodes = []uint64{uint64(v1)}
+			query.TableName = os.Args[len(os.Args)-1]
+			if len(os.Args) == 6 {
+				metrics := map[string]int{
+					"jaccard":         bagel.LINK_METRIC_JACCARD,
+					"adamicadar":      bagel.LINK_METRIC_ADAMIC_ADAR,
+					"commonneighbors": bagel.LINK_METRIC_COMMON_NEIGHBORS,
+				}
+				metric, exists := metrics[strings.ToLower(os.Args[4])]
+				invalidInput = !exists
+				query.Params = map[string]float64{
+					bagel.LINK_PREDICTION_METRIC: float64(metric),
+				}
+			}
+		}
+	} else if strings.EqualFold(os.Args[1], bagel.BETWEENNESS) {
+		// estimate the betweenness of the vertex from numSamples sources
+		numSamples, err := strconv.Atoi(os.Args[2])
+		if len(os.Args) != 5 || err != nil || numSamples <= 0 {
+			invalidInput = true
+		} else if v1, err := strconv.Atoi(os.Args[3]); err != nil {
+			log.Println("Provided vertex could not be converted to integer")
+			invalidInput = true
+		} else {
+			query.QueryType = bagel.BETWEENNESS
+			query.Nodes = []uint64{uint64(v1)}
+			query.Params = map[string]float64{
+				bagel.BETWEENNESS_NUM_SAMPLES: float64(numSamples),
+			}
+			query.TableName = os.Args[4]
+		}
+	} else if strings.EqualFold(os.Args[1], topK) {
+		k, err := strconv.Atoi(os.Args[2])
+		if len(os.Args) != 5 || err != nil || k <= 0 {
+			invalidInput = true
+		} else {
+			query.ResultMode = bagel.RESULT_TOP_K
+			query.TopK = uint32(k)
+			query.TableName = os.Args[4]
+			for _, queryType := range []string{
+				bagel.PAGE_RANK, bagel.DEGREE, bagel.CONNECTED_COMPONENTS,
+				bagel.STRONGLY_CONNECTED_COMPONENTS, bagel.TRIANGLE_COUNT,
+				bagel.LABEL_PROPAGATION, bagel.HITS, bagel.K_CORE,
+				bagel.BETWEENNESS,
+			} {
+				if strings.EqualFold(os.Args[3], queryType) {
+					query.QueryType = queryType
+				}
+			}
+			invalidInput = query.QueryType == ""
+		}
+	} else if strings.EqualFold(os.Args[1], bagel.PERSONALIZED_PAGE_RANK) {
+		// rank the vertices most relevant to a comma separated list of seeds
+		k, err := strconv.Atoi(os.Args[2])
+		if len(os.Args) != 5 || err != nil || k <= 0 {
+			invalidInput = true
+		} else {
+			query.QueryType = bagel.PERSONALIZED_PAGE_RANK
+			query.ResultMode = bagel.RESULT_TOP_K
+			query.TopK = uint32(k)
+			query.TableName = os.Args[4]
+			for _, seed := range strings.Split(os.Args[3], ",") {
+				v1, err := strconv.Atoi(seed)
+				if err != nil {
+					log.Println("Provided vertex could not be converted to integer")
+					invalidInput = true
+				}
+				query.Nodes = append(query.Nodes, uint64(v1))
+			}
+		}
+	} else if strings.EqualFold(os.Args[1], bagel.K_HOP_NEIGHBORHOOD) {
+		// count the vertices within depth hops of a comma separated list of
+		// sources
+		depth, err := strconv.Atoi(os.Args[2])
+		if len(os.Args) != 5 || err != nil || depth < 0 {
+			invalidInput = true
+		} else {
+			query.QueryType = bagel.K_HOP_NEIGHBORHOOD
+			query.Params = map[string]float64{
+				bagel.K_HOP_MAX_DEPTH: float64(depth),
+			}
+			query.TableName = os.Args[4]
+			for _, source := range strings.Split(os.Args[3], ",") {
+				v1, err := strconv.Atoi(source)
+				if err != nil {
+					log.Println("Provided vertex could not be converted to integer")
+					invalidInput = true
+				}
+				query.Nodes = append(query.Nodes, uint64(v1))
+			}
+		}
 	} else {
 		invalidInput = true
 	}
 
 	if invalidInput {
-		log.Println("Usage: ./bin/client [shortestpath|pagerank] [vertexId] [vertexId] [tableName]")
+		log.Println("Usage: ./bin/client [shortestpath|weightedshortestpath|pagerank|degree|connectedcomponents|stronglyconnectedcomponents|trianglecount|labelpropagation|personalizedpagerank|hits|kcore|graphcoloring|khopneighborhood|hyperanf|minimumspanningforest|randomwalk|linkprediction|betweenness|top] [vertexId] [vertexId] [tableName]")
 		log.Println("Example: ./bin/client pagerank 11 bagelDb")
 		log.Println("Example: ./bin/client shortestpath 11 54 bagelDB")
+		log.Println("Example: ./bin/client weightedshortestpath 11 54 bagelDB")
+		log.Println("Example: ./bin/client connectedcomponents 11 bagelDB")
+		log.Println("Example: ./bin/client connectedcomponents bagelDB")
+		log.Println("Example: ./bin/client stronglyconnectedcomponents 11 bagelDB")
+		log.Println("Example: ./bin/client stronglyconnectedcomponents bagelDB")
+		log.Println("Example: ./bin/client trianglecount 11 bagelDB")
+		log.Println("Example: ./bin/client trianglecount bagelDB")
+		log.Println("Example: ./bin/client labelpropagation 11 bagelDB")
+		log.Println("Example: ./bin/client labelpropagation bagelDB")
+		log.Println("Example: ./bin/client degree 11 bagelDB")
+		log.Println("Example: ./bin/client hits 11 bagelDB")
+		log.Println("Example: ./bin/client kcore 11 bagelDB")
+		log.Println("Example: ./bin/client kcore bagelDB")
+		log.Println("Example: ./bin/client graphcoloring 11 bagelDB")
+		log.Println("Example: ./bin/client khopneighborhood 2 11,54 bagelDB")
+		log.Println("Example: ./bin/client hyperanf bagelDB")
+		log.Println("Example: ./bin/client minimumspanningforest bagelDB")
+		log.Println("Example: ./bin/client randomwalk 10 80 bagelDB")
+		log.Println("Example: ./bin/client randomwalk 10 80 1 0.5 bagelDB")
+		log.Println("Example: ./bin/client personalizedpagerank 10 11,54 bagelDB")
+		log.Println("Example: ./bin/client linkprediction 10 11 bagelDB")
+		log.Println("Example: ./bin/client linkprediction 10 11 adamicadar bagelDB")
+		log.Println("Example: ./bin/client betweenness 32 11 bagelDB")
+		log.Println("Example: ./bin/client top 100 [pagerank|degree|connectedcomponents|stronglyconnectedcomponents|trianglecount|labelpropagation|hits|kcore|betweenness] bagelDB")
 		return
 	}
 
@@ -97,6 +311,15 @@ func main() {
 			log.Printf("Client: SendQuery error: %v\n", result.Error)
 		}
 		log.Printf("Client: SendQuery received result: %v\n", result.Result)
+		if len(result.Path) > 0 {
+			log.Printf("Client: SendQuery received path: %v\n", result.Path)
+		}
+		for rank, rankedVertex := range result.Ranking {
+			log.Printf(
+				"Client: #%v vertex %v with %v\n", rank+1,
+				rankedVertex.VertexId, rankedVertex.Score,
+			)
+		}
 	}
 
 }
@@ -24,7 +24,12 @@ type Graph map[uint64][]uint64
 type Vertex struct {
 	ID    uint64
 	Edges []uint64
-	Hash  uint64
+	// InEdges holds the sources of the edges to the vertex
+	InEdges []uint64
+	// Weights holds the weight of each edge in Edges, or is empty if the
+	// graph is unweighted
+	Weights []float64
+	Hash    uint64
 }
 
 var DB *dynamodb.Client
@@ -307,6 +312,12 @@ func marshalVertexWriteReq(vertex Vertex) types.WriteRequest {
 					),
 				},
 				"Edges": &types.AttributeValueMemberL{Value: edgesToAttributeValueSlice(vertex.Edges)},
+				"InEdges": &types.AttributeValueMemberL{
+					Value: edgesToAttributeValueSlice(vertex.InEdges),
+				},
+				"Weights": &types.AttributeValueMemberL{
+					Value: weightsToAttributeValueSlice(vertex.Weights),
+				},
 				"Hash": &types.AttributeValueMemberN{
 					Value: strconv.FormatUint(
 						vertex.Hash, 10,
@@ -331,6 +342,12 @@ func marshalVertexForPartition(
 					),
 				},
 				"Edges": &types.AttributeValueMemberL{Value: edgesToAttributeValueSlice(vertex.Edges)},
+				"InEdges": &types.AttributeValueMemberL{
+					Value: edgesToAttributeValueSlice(vertex.InEdges),
+				},
+				"Weights": &types.AttributeValueMemberL{
+					Value: weightsToAttributeValueSlice(vertex.Weights),
+				},
 				"Hash": &types.AttributeValueMemberN{
 					Value: strconv.FormatUint(
 						vertex.Hash, 10,
@@ -356,6 +373,18 @@ func edgesToAttributeValueSlice(edges []uint64) []types.AttributeValue {
 	return as
 }
 
+func weightsToAttributeValueSlice(weights []float64) []types.AttributeValue {
+	as := make([]types.AttributeValue, len(weights))
+	for idx, weight := range weights {
+		as[idx] = &types.AttributeValueMemberN{
+			Value: strconv.FormatFloat(
+				weight, 'g', -1, 64,
+			),
+		}
+	}
+	return as
+}
+
 func getNumberOfWorkersXName(x int) string {
 	return fmt.Sprintf("P%d", x)
 }
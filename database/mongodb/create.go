@@ -66,6 +66,14 @@ func createBatches(vertices []database.Vertex) [][]interface{} {
 				"Edges",
 				formatEdges(vertex.Edges),
 			},
+			{
+				"InEdges",
+				formatEdges(vertex.InEdges),
+			},
+			{
+				"Weights",
+				formatWeights(vertex.Weights),
+			},
 			{
 				"Hash", strconv.FormatUint(
 					vertex.Hash, 10,
@@ -85,6 +93,14 @@ func formatEdges(edges []uint64) []string {
 	return formattedEdges
 }
 
+func formatWeights(weights []float64) []string {
+	formattedWeights := make([]string, len(weights))
+	for idx, weight := range weights {
+		formattedWeights[idx] = strconv.FormatFloat(weight, 'g', -1, 64)
+	}
+	return formattedWeights
+}
+
 func BatchInsertVertices(
 	collection *mongo.Collection,
 	batches [][]interface{},
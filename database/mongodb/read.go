@@ -12,15 +12,22 @@ import (
 )
 
 type DBVertex struct {
-	ID    string
-	Edges []string
-	Hash  string
+	ID      string
+	Edges   []string
+	InEdges []string
+	Weights []string
+	Hash    string
 }
 
 type Vertex struct {
 	ID    uint64
 	Edges []uint64
-	Hash  uint64
+	// InEdges holds the sources of the edges to the vertex
+	InEdges []uint64
+	// Weights holds the weight of each edge in Edges, or is empty if the
+	// graph is unweighted
+	Weights []float64
+	Hash    uint64
 }
 
 func GetVertexById(
@@ -46,12 +53,27 @@ func parseDBVertex(dbVertex DBVertex) Vertex {
 		edges[idx], _ = strconv.ParseUint(edge, 10, 64)
 	}
 
+	inEdges := make([]uint64, len(dbVertex.InEdges))
+	for idx, edge := range dbVertex.InEdges {
+		inEdges[idx], _ = strconv.ParseUint(edge, 10, 64)
+	}
+
+	var weights []float64
+	if len(dbVertex.Weights) == len(dbVertex.Edges) {
+		weights = make([]float64, len(dbVertex.Weights))
+		for idx, weight := range dbVertex.Weights {
+			weights[idx], _ = strconv.ParseFloat(weight, 64)
+		}
+	}
+
 	hash, _ := strconv.ParseUint(dbVertex.Hash, 10, 64)
 
 	return Vertex{
-		ID:    id,
-		Edges: edges,
-		Hash:  hash,
+		ID:      id,
+		Edges:   edges,
+		InEdges: inEdges,
+		Weights: weights,
+		Hash:    hash,
 	}
 }
 
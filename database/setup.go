@@ -96,8 +96,16 @@ func waitForTable(ctx context.Context, db *dynamodb.Client, tn string) error {
 	return err
 }
 
+// ParseInputGraph reads a graph with one "src,dest" edge per line. An
+// optional third column gives the weight of the edge; if no edge of the
+// graph has a weight, the vertices are stored without weights and every edge
+// has an implicit weight of 1. The in-edges of every vertex are stored along
+// with its out-edges
 func ParseInputGraph(filePath string) []Vertex {
 	graph := make(map[uint64][]uint64)
+	inEdges := make(map[uint64][]uint64)
+	weights := make(map[uint64][]float64)
+	isWeighted := false
 
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -118,25 +126,52 @@ func ParseInputGraph(filePath string) []Vertex {
 		src, _ := strconv.ParseUint(edge[0], 10, 32)
 		dest, _ := strconv.ParseUint(edge[1], 10, 32)
 
+		weight := 1.0
+		if len(edge) > 2 {
+			weight, err = strconv.ParseFloat(strings.TrimSpace(edge[2]), 64)
+			if err != nil || weight < 0 {
+				panic(fmt.Sprintf("invalid weight on edge %v: %v", line, err))
+			}
+			isWeighted = true
+		}
+
 		graph[uint64(src)] = append(graph[uint64(src)], uint64(dest))
+		inEdges[uint64(dest)] = append(inEdges[uint64(dest)], uint64(src))
+		weights[uint64(src)] = append(weights[uint64(src)], weight)
 		if graph[uint64(dest)] == nil {
 			graph[uint64(dest)] = []uint64{}
 		}
 	}
 	fmt.Printf("Successfully parsed %v nodes\n", len(graph))
-	return graphToVertices(graph)
+
+	if !isWeighted {
+		weights = nil
+	}
+	return graphToVertices(graph, inEdges, weights)
 }
 
-func graphToVertices(graph Graph) []Vertex {
+func graphToVertices(
+	graph Graph, inEdges Graph, weights map[uint64][]float64,
+) []Vertex {
 	vertices := make([]Vertex, len(graph))
 
 	idx := 0
 	for vertexId, edges := range graph {
 		hash := util.HashId(vertexId)
 		vertices[idx] = Vertex{
-			ID:    vertexId,
-			Edges: edges,
-			Hash:  hash,
+			ID:      vertexId,
+			Edges:   edges,
+			InEdges: inEdges[vertexId],
+			Hash:    hash,
+		}
+		if vertices[idx].InEdges == nil {
+			vertices[idx].InEdges = []uint64{}
+		}
+		if weights != nil {
+			vertices[idx].Weights = weights[vertexId]
+			if vertices[idx].Weights == nil {
+				vertices[idx].Weights = []float64{}
+			}
 		}
 		idx++
 	}